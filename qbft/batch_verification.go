@@ -0,0 +1,128 @@
+package qbft
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+// verifyMsgSignature verifies signedMsg's signature by operators, skipped if already verified (see markSignatureVerified).
+// A valid signature is marked as verified, attributing later rejections of the msg to its signers (see Misbehavior)
+func verifyMsgSignature(config IConfig, signedMsg *SignedMessage, operators []*types.Operator) error {
+	if signedMsg.isSignatureVerified(config, operators) {
+		return nil
+	}
+	if err := signedMsg.Signature.VerifyByOperators(signedMsg.withVersion(config.GetEncodingVersion()), config.GetSignatureDomainType(), types.QBFTSignatureType, operators); err != nil {
+		return err
+	}
+	signedMsg.markSignatureVerified(config, operators)
+	return nil
+}
+
+// verificationDigest returns a digest of the msg's signature, signers and root (with config's encoding version), and of the signature domain and operators verifying it, nil if the root can't be calculated
+func (signedMsg *SignedMessage) verificationDigest(config IConfig, operators []*types.Operator) []byte {
+	if signedMsg.Message == nil {
+		return nil
+	}
+	r, err := signedMsg.GetRootWithVersion(config.GetEncodingVersion())
+	if err != nil {
+		return nil
+	}
+
+	h := sha256.New()
+	h.Write(signedMsg.Signature)
+	for _, signer := range signedMsg.Signers {
+		byts := make([]byte, 8)
+		binary.BigEndian.PutUint64(byts, uint64(signer))
+		h.Write(byts)
+	}
+	h.Write(r)
+	h.Write(config.GetSignatureDomainType())
+	for _, operator := range operators {
+		byts := make([]byte, 8)
+		binary.BigEndian.PutUint64(byts, uint64(operator.OperatorID))
+		h.Write(byts)
+		h.Write(operator.PubKey)
+	}
+	return h.Sum(nil)
+}
+
+// markSignatureVerified marks the msg's signature as verified with config's domain by operators, as long as the msg's signature, signers and msg are not changed
+func (signedMsg *SignedMessage) markSignatureVerified(config IConfig, operators []*types.Operator) {
+	signedMsg.verifiedDigest = signedMsg.verificationDigest(config, operators)
+}
+
+// isSignatureVerified returns true if the msg's signature was marked verified with config's domain by operators and the msg didn't change since
+func (signedMsg *SignedMessage) isSignatureVerified(config IConfig, operators []*types.Operator) bool {
+	return signedMsg.verifiedDigest != nil && bytes.Equal(signedMsg.verifiedDigest, signedMsg.verificationDigest(config, operators))
+}
+
+// batchVerifyMsgSignatures verifies all msgs' signatures by operators with a single batch verification, marking valid ones as verified.
+// If the batch fails each msg is verified on its own. Msgs that can't be verified (invalid, unknown signer, etc.) are left unmarked to fail their own processing
+func batchVerifyMsgSignatures(config IConfig, msgs []*SignedMessage, operators []*types.Operator) {
+	verifier := types.NewBatchVerifier()
+	added := make([]*SignedMessage, 0)
+	for _, msg := range msgs {
+		if msg == nil || msg.isSignatureVerified(config, operators) || msg.Validate() != nil {
+			continue
+		}
+		if err := verifier.AddByOperators(msg.withVersion(config.GetEncodingVersion()), config.GetSignatureDomainType(), types.QBFTSignatureType, operators); err != nil {
+			continue
+		}
+		added = append(added, msg)
+	}
+
+	if verifier.Verify() {
+		for _, msg := range added {
+			msg.markSignatureVerified(config, operators)
+		}
+		return
+	}
+
+	// fallback, find the invalid signatures
+	for _, msg := range added {
+		if err := msg.Signature.VerifyByOperators(msg.withVersion(config.GetEncodingVersion()), config.GetSignatureDomainType(), types.QBFTSignatureType, operators); err == nil {
+			msg.markSignatureVerified(config, operators)
+		}
+	}
+}
+
+// ProcessMsgs processes msgs in order, verifying all signatures with a single batch verification first (falling back to verifying each msg on its own if the batch fails).
+// Msgs are screened (see screenMsgLimits) before the batch verification so msgs rejected by the config's RateLimit are never verified.
+// Returns the decided msg and error of processing each msg, index aligned with msgs
+func (c *Controller) ProcessMsgs(msgs []*SignedMessage) ([]*SignedMessage, []error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	decided := make([]*SignedMessage, len(msgs))
+	errs := make([]error, len(msgs))
+	screened := make([]*SignedMessage, 0, len(msgs))
+	batchCounts := make(map[rateLimitKey]int)
+	for idx, msg := range msgs {
+		if msg == nil {
+			errs[idx] = errors.New("msg is nil")
+			continue
+		}
+		if err := c.baseMsgValidation(msg); err != nil {
+			errs[idx] = errors.Wrap(err, "invalid msg")
+			continue
+		}
+		if _, _, err := c.screenMsgLimits(msg, batchCounts); err != nil {
+			errs[idx] = errors.Wrap(err, "invalid msg")
+			continue
+		}
+		screened = append(screened, msg)
+	}
+
+	batchVerifyMsgSignatures(c.GetConfig(), screened, c.Share.Committee)
+
+	for idx, msg := range msgs {
+		if errs[idx] != nil {
+			continue
+		}
+		decided[idx], errs[idx] = c.processMsg(msg)
+	}
+	return decided, errs
+}
@@ -0,0 +1,132 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestController_ProcessMsgs(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	setup := func() *qbft.Controller {
+		identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+		c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), testingutils.TestingConfig(ks))
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		return c
+	}
+
+	msgs := func(c *qbft.Controller) []*qbft.SignedMessage {
+		msg := func(id types.OperatorID, msgType qbft.MessageType, data []byte) *qbft.SignedMessage {
+			return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+				MsgType:    msgType,
+				Height:     c.Height,
+				Round:      qbft.FirstRound,
+				Identifier: c.Identifier,
+				Data:       data,
+			})
+		}
+		ret := []*qbft.SignedMessage{msg(1, qbft.ProposalMsgType, testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil))}
+		for _, id := range []types.OperatorID{1, 2, 3} {
+			ret = append(ret, msg(id, qbft.PrepareMsgType, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4})))
+		}
+		for _, id := range []types.OperatorID{1, 2, 3} {
+			ret = append(ret, msg(id, qbft.CommitMsgType, testingutils.CommitDataBytes([]byte{1, 2, 3, 4})))
+		}
+		return ret
+	}
+
+	t.Run("valid batch", func(t *testing.T) {
+		c := setup()
+		decided, errs := c.ProcessMsgs(msgs(c))
+		require.Len(t, decided, 7)
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		require.Nil(t, decided[5])
+		require.NotNil(t, decided[6])
+
+		highest, err := c.GetConfig().GetStorage().GetHighestDecided(c.Identifier)
+		require.NoError(t, err)
+		require.EqualValues(t, []types.OperatorID{1, 2, 3}, highest.Signers)
+	})
+
+	t.Run("invalid signature fallback", func(t *testing.T) {
+		c := setup()
+		batch := msgs(c)
+		// prepare of operator 2 signed by operator 4
+		batch[2].Signature = testingutils.SignQBFTMsg(ks.Shares[4], 2, batch[2].Message).Signature
+
+		decided, errs := c.ProcessMsgs(batch)
+		for idx, err := range errs {
+			if idx == 2 {
+				require.EqualError(t, err, "could not process msg: invalid prepare msg: prepare msg signature invalid: failed to verify signature")
+			} else {
+				require.NoError(t, err)
+			}
+		}
+		require.NotNil(t, decided[6]) // commit quorum decides regardless of the invalid prepare
+	})
+
+	t.Run("screened before verification", func(t *testing.T) {
+		misbehaviors := make([]*qbft.Misbehavior, 0)
+		config := testingutils.TestingConfig(ks)
+		config.RateLimit = &qbft.RateLimit{MaxMsgsPerRound: 1}
+		config.MisbehaviorF = func(misbehavior *qbft.Misbehavior) {
+			misbehaviors = append(misbehaviors, misbehavior)
+		}
+		identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+		c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+
+		batch := msgs(c)
+		// a second prepare of operator 2 with an invalid signature is rejected by the rate limit, not by its signature
+		invalid := testingutils.SignQBFTMsg(ks.Shares[4], 2, batch[2].Message)
+		batch = append(batch, invalid)
+
+		_, errs := c.ProcessMsgs(batch)
+		for _, err := range errs[:7] {
+			require.NoError(t, err)
+		}
+		require.EqualError(t, errs[7], "invalid msg: msg rate limited: too many msgs for round")
+		require.Len(t, misbehaviors, 1)
+		require.EqualValues(t, qbft.RateLimitedMisbehavior, misbehaviors[0].Type)
+	})
+
+	t.Run("mutated msg verified again", func(t *testing.T) {
+		c := setup()
+		batch := msgs(c)
+		_, errs := c.ProcessMsgs(batch)
+		require.NoError(t, errs[2])
+
+		// the prepare was verified, changing its signature must not reuse the verification
+		batch[2].Signature = testingutils.SignQBFTMsg(ks.Shares[4], 2, batch[2].Message).Signature
+		c = setup()
+		_, err := c.ProcessMsg(batch[0])
+		require.NoError(t, err)
+		_, err = c.ProcessMsg(batch[2])
+		require.EqualError(t, err, "could not process msg: invalid prepare msg: prepare msg signature invalid: failed to verify signature")
+	})
+
+	t.Run("verified with another domain", func(t *testing.T) {
+		c := setup()
+		batch := msgs(c)
+		_, errs := c.ProcessMsgs(batch)
+		require.NoError(t, errs[0])
+
+		// the proposal was verified with the testing domain, a controller of another domain must verify it again
+		config := testingutils.TestingConfig(ks)
+		config.Domain = types.DomainType{0x1, 0x2, 0x3, 0x4}
+		other := testingutils.NewTestingQBFTController(c.Identifier, testingutils.TestingShare(ks), config)
+		require.NoError(t, other.StartNewInstance([]byte{1, 2, 3, 4}))
+		_, err := other.ProcessMsg(batch[0])
+		require.EqualError(t, err, "could not process msg: proposal invalid: proposal msg signature invalid: failed to verify signature")
+	})
+
+	t.Run("nil msg", func(t *testing.T) {
+		c := setup()
+		_, errs := c.ProcessMsgs([]*qbft.SignedMessage{nil})
+		require.EqualError(t, errs[0], "msg is nil")
+	})
+}
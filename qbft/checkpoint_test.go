@@ -0,0 +1,70 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// testingCheckpointStorage records the number of msgs broadcasted whenever a snapshot is saved
+type testingCheckpointStorage struct {
+	net        *testingutils.TestingNetwork
+	broadcasts []int
+	err        error
+}
+
+func (s *testingCheckpointStorage) SaveControllerSnapshot(identifier []byte, data []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.broadcasts = append(s.broadcasts, len(s.net.BroadcastedMsgs))
+	return nil
+}
+
+func (s *testingCheckpointStorage) GetControllerSnapshot(identifier []byte) ([]byte, error) {
+	return nil, nil
+}
+
+func TestController_CheckpointBeforeBroadcast(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	setup := func(err error) (*qbft.Controller, *qbft.Config, *testingCheckpointStorage) {
+		config := testingutils.TestingConfig(ks)
+		storage := &testingCheckpointStorage{net: config.GetNetwork().(*testingutils.TestingNetwork), err: err}
+		config.ControllerStorage = storage
+		return testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config), config, storage
+	}
+
+	t.Run("start instance", func(t *testing.T) {
+		c, config, storage := setup(nil)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.EqualValues(t, []int{0}, storage.broadcasts)
+		require.Len(t, config.GetNetwork().(*testingutils.TestingNetwork).BroadcastedMsgs, 1) // proposal
+	})
+
+	t.Run("process msg", func(t *testing.T) {
+		c, config, storage := setup(nil)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		_, err := c.ProcessMsg(testingutils.SignQBFTMsg(ks.Shares[1], 1, &qbft.Message{
+			MsgType:    qbft.ProposalMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: identifier[:],
+			Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil),
+		}))
+		require.NoError(t, err)
+		require.EqualValues(t, []int{0, 1}, storage.broadcasts)
+		require.Len(t, config.GetNetwork().(*testingutils.TestingNetwork).BroadcastedMsgs, 2) // proposal, prepare
+	})
+
+	t.Run("checkpoint failed", func(t *testing.T) {
+		c, config, _ := setup(errors.New("disk full"))
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.NotNil(t, c.InstanceForHeight(qbft.FirstHeight))
+		require.Len(t, config.GetNetwork().(*testingutils.TestingNetwork).BroadcastedMsgs, 0)
+		require.EqualValues(t, []string{"could not save controller snapshot: disk full"}, config.Logger.(*testingutils.TestingLogger).Errors())
+	})
+}
@@ -1,23 +1,18 @@
 package qbft
 
 import (
-	"bytes"
 	"github.com/bloxapp/ssv-spec/types"
 	"github.com/pkg/errors"
 )
 
 // UponCommit returns true if a quorum of commit messages was received.
+// As in the formal spec, commits are valid for any round of the instance's height (not only the current round) and a quorum of commits for the same round and value decides,
+// whether or not the instance is in that round or accepted a proposal for that value (e.g. an instance left behind in a previous round or following an equivocating proposer)
 func (i *Instance) UponCommit(signedCommit *SignedMessage, commitMsgContainer *MsgContainer) (bool, []byte, *SignedMessage, error) {
-	if i.State.ProposalAcceptedForCurrentRound == nil {
-		return false, nil, nil, errors.New("did not receive proposal for this round")
-	}
-
 	if err := validateCommit(
 		i.config,
 		signedCommit,
 		i.State.Height,
-		i.State.Round,
-		i.State.ProposalAcceptedForCurrentRound,
 		i.State.Share.Committee,
 	); err != nil {
 		return false, nil, nil, errors.Wrap(err, "commit msg invalid")
@@ -51,7 +46,7 @@ func (i *Instance) UponCommit(signedCommit *SignedMessage, commitMsgContainer *M
 	return false, nil, nil, nil
 }
 
-// returns true if there is a quorum for the current round for this provided value
+// returns true if there is a quorum for the provided round and value
 func commitQuorumForRoundValue(state *State, commitMsgContainer *MsgContainer, value []byte, round Round) (bool, []*SignedMessage, error) {
 	signers, msgs := commitMsgContainer.LongestUniqueSignersForRoundAndValue(round, value)
 	return state.Share.HasQuorum(len(signers)), msgs, nil
@@ -110,7 +105,7 @@ func CreateCommit(state *State, config IConfig, value []byte) (*SignedMessage, e
 	commitData := &CommitData{
 		Data: value,
 	}
-	dataByts, err := commitData.Encode()
+	dataByts, err := commitData.EncodeWithVersion(config.GetEncodingVersion())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed encoding prepare data")
 	}
@@ -121,7 +116,7 @@ func CreateCommit(state *State, config IConfig, value []byte) (*SignedMessage, e
 		Identifier: state.ID,
 		Data:       dataByts,
 	}
-	sig, err := config.GetSigner().SignRoot(msg, types.QBFTSignatureType, state.Share.SharePubKey)
+	sig, err := config.GetSigner().SignRoot(msg.withVersion(config.GetEncodingVersion()), types.QBFTSignatureType, state.Share.SharePubKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed signing commit msg")
 	}
@@ -156,19 +151,18 @@ func baseCommitValidation(
 	}
 
 	// verify signature
-	if err := signedCommit.Signature.VerifyByOperators(signedCommit, config.GetSignatureDomainType(), types.QBFTSignatureType, operators); err != nil {
+	if err := verifyMsgSignature(config, signedCommit, operators); err != nil {
 		return errors.Wrap(err, "commit msg signature invalid")
 	}
 
 	return nil
 }
 
+// validateCommit returns nil if signedCommit is a valid single signer commit for height, of any round and value
 func validateCommit(
 	config IConfig,
 	signedCommit *SignedMessage,
 	height Height,
-	round Round,
-	proposedMsg *SignedMessage,
 	operators []*types.Operator,
 ) error {
 	if err := baseCommitValidation(config, signedCommit, height, operators); err != nil {
@@ -179,23 +173,5 @@ func validateCommit(
 		return errors.New("commit msgs allow 1 signer")
 	}
 
-	if signedCommit.Message.Round != round {
-		return errors.New("commit round is wrong")
-	}
-
-	proposedCommitData, err := proposedMsg.Message.GetCommitData()
-	if err != nil {
-		return errors.Wrap(err, "could not get proposed commit data")
-	}
-
-	msgCommitData, err := signedCommit.Message.GetCommitData()
-	if err != nil {
-		return errors.Wrap(err, "could not get msg commit data")
-	}
-
-	if !bytes.Equal(proposedCommitData.Data, msgCommitData.Data) {
-		return errors.New("proposed data different than commit msg data")
-	}
-
 	return nil
 }
@@ -4,47 +4,28 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/json"
-	"fmt"
 	"github.com/bloxapp/ssv-spec/types"
 	"github.com/pkg/errors"
+	"sync"
 )
 
-// HistoricalInstanceCapacity represents the upper bound of InstanceContainer a processmsg can process messages for as messages are not
-// guaranteed to arrive in a timely fashion, we physically limit how far back the processmsg will process messages for
-const HistoricalInstanceCapacity int = 5
-
-type InstanceContainer [HistoricalInstanceCapacity]*Instance
-
-func (i InstanceContainer) FindInstance(height Height) *Instance {
-	for _, inst := range i {
-		if inst != nil {
-			if inst.GetHeight() == height {
-				return inst
-			}
-		}
-	}
-	return nil
-}
-
-// addNewInstance will add the new instance at index 0, pushing all other stored InstanceContainer one index up (ejecting last one if existing)
-func (i *InstanceContainer) addNewInstance(instance *Instance) {
-	for idx := HistoricalInstanceCapacity - 1; idx > 0; idx-- {
-		i[idx] = i[idx-1]
-	}
-	i[0] = instance
-}
-
 // Controller is a QBFT coordinator responsible for starting and following the entire life cycle of multiple QBFT InstanceContainer
 type Controller struct {
 	Identifier []byte
 	Height     Height // incremental Height for InstanceContainer
-	// StoredInstances stores the last HistoricalInstanceCapacity in an array for message processing purposes.
-	StoredInstances InstanceContainer
+	// StoredInstances stores the last (configurable, HistoricalInstanceCapacity by default) instances for message processing purposes.
+	StoredInstances *InstanceContainer
 	// FutureMsgsContainer holds all msgs from a higher height
 	FutureMsgsContainer map[types.OperatorID]Height // maps msg signer to height of higher height received msgs
 	Domain              types.DomainType
 	Share               *types.Share
 	config              IConfig
+	// rateLimiter counts msgs for the config's RateLimit, created on first use
+	rateLimiter *rateLimiter
+	// pendingBroadcasts holds the msgs broadcasted since the last checkpoint, see checkpoint
+	pendingBroadcasts []*types.SSVMessage
+	// lock serializes the controller's entry points (e.g. ProcessMsg and UponRoundTimeout called by timer goroutines)
+	lock sync.Mutex
 }
 
 func NewController(
@@ -58,14 +39,65 @@ func NewController(
 		Height:              -1, // as we bump the height when starting the first instance
 		Domain:              domain,
 		Share:               share,
-		StoredInstances:     InstanceContainer{},
+		StoredInstances:     NewInstanceContainer(config.GetHistoricalInstanceCapacity()),
 		FutureMsgsContainer: make(map[types.OperatorID]Height),
 		config:              config,
 	}
 }
 
-// StartNewInstance will start a new QBFT instance, if can't will return error
+// NewControllerFromStorage returns a controller restored from the snapshot found in the config's ControllerStorage.
+// If no snapshot was found a new controller is returned.
+// Restored running (not decided or stopped) instances have their timers re-armed for the restored round.
+// If the configured historical instance capacity changed, the restored instances are resized to it.
+func NewControllerFromStorage(
+	identifier []byte,
+	share *types.Share,
+	domain types.DomainType,
+	config IConfig,
+) (*Controller, error) {
+	storage := config.GetControllerStorage()
+	if storage == nil {
+		return nil, errors.New("controller storage not configured")
+	}
+
+	byts, err := storage.GetControllerSnapshot(identifier)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get controller snapshot")
+	}
+	if byts == nil {
+		return NewController(identifier, share, domain, config), nil
+	}
+
+	ret := &Controller{
+		config: config,
+	}
+	if err := ret.Decode(byts); err != nil {
+		return nil, errors.Wrap(err, "could not restore controller")
+	}
+	if !bytes.Equal(identifier, ret.Identifier) {
+		return nil, errors.New("controller snapshot doesn't belong to Identifier")
+	}
+
+	if capacity := NewInstanceContainer(config.GetHistoricalInstanceCapacity()).Capacity(); capacity != ret.StoredInstances.Capacity() {
+		for _, evicted := range ret.StoredInstances.resize(capacity) {
+			ret.onInstanceEvicted(evicted)
+		}
+	}
+
+	for _, inst := range ret.StoredInstances.Instances() {
+		if decided, _ := inst.IsDecided(); !decided && !inst.IsStopped() {
+			inst.timeoutForRound(inst.State.Round)
+		}
+	}
+	return ret, nil
+}
+
+// StartNewInstance will start a new QBFT instance, if can't will return error.
+// An error is returned only if the instance didn't start, failing to checkpoint the started instance is logged (its msgs are not broadcasted, see checkpoint)
 func (c *Controller) StartNewInstance(value []byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	if err := c.canStartInstance(c.Height+1, value); err != nil {
 		return errors.Wrap(err, "can't start new QBFT instance")
 	}
@@ -74,14 +106,48 @@ func (c *Controller) StartNewInstance(value []byte) error {
 	newInstance := c.addAndStoreNewInstance()
 	newInstance.Start(value, c.Height)
 
+	if err := c.checkpoint(); err != nil {
+		// the instance started, no need to fail starting it if failed to checkpoint
+		c.logError(err, nil)
+	}
+	return nil
+}
+
+// StopInstance stops the instance for height (see Instance.Stop) and checkpoints the controller.
+// The next instance can start once the stopped instance stopped, as if it decided
+func (c *Controller) StopInstance(height Height) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	inst := c.InstanceForHeight(height)
+	if inst == nil {
+		return errors.New("instance not found")
+	}
+
+	inst.Stop()
+	if err := c.checkpoint(); err != nil {
+		return errors.Wrap(err, "could not checkpoint controller")
+	}
 	return nil
 }
 
-// ProcessMsg processes a new msg, returns decided message or error
+// ProcessMsg processes a new msg, returns decided message or error.
+// Controller entry points (ProcessMsg, StartNewInstance, StopInstance, UponRoundTimeout and sync responses) are serialized, they must not be called from the controller's callbacks (e.g. an Observer)
 func (c *Controller) ProcessMsg(msg *SignedMessage) (*SignedMessage, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.processMsg(msg)
+}
+
+// processMsg is ProcessMsg without serialization, for callers already holding the lock
+func (c *Controller) processMsg(msg *SignedMessage) (*SignedMessage, error) {
 	if err := c.baseMsgValidation(msg); err != nil {
 		return nil, errors.Wrap(err, "invalid msg")
 	}
+	if err := c.screenMsg(msg); err != nil {
+		return nil, errors.Wrap(err, "invalid msg")
+	}
 
 	/**
 	Main controller processing flow
@@ -90,13 +156,21 @@ func (c *Controller) ProcessMsg(msg *SignedMessage) (*SignedMessage, error) {
 	All valid future msgs are saved in a container and can trigger highest decided futuremsg
 	All other msgs (not future or decided) are processed normally by an existing instance (if found)
 	*/
+	var decided *SignedMessage
+	var err error
 	if isDecidedMsg(c.Share, msg) {
-		return c.UponDecided(msg)
+		decided, err = c.UponDecided(msg)
 	} else if msg.Message.Height > c.Height {
-		return c.UponFutureMsg(msg)
+		decided, err = c.UponFutureMsg(msg)
 	} else {
-		return c.UponExistingInstanceMsg(msg)
+		decided, err = c.UponExistingInstanceMsg(msg)
+	}
+
+	if checkpointErr := c.checkpoint(); checkpointErr != nil {
+		// no need to fail processing the msg if failed to checkpoint
+		c.logError(checkpointErr, msg)
 	}
+	return decided, err
 }
 
 func (c *Controller) UponExistingInstanceMsg(msg *SignedMessage) (*SignedMessage, error) {
@@ -109,11 +183,17 @@ func (c *Controller) UponExistingInstanceMsg(msg *SignedMessage) (*SignedMessage
 
 	decided, _, decidedMsg, err := inst.ProcessMsg(msg)
 	if err != nil {
+		if isProvenMisbehavior(err) {
+			c.reportMisbehavior(RejectedMsgMisbehavior, msg, err)
+		}
 		return nil, errors.Wrap(err, "could not process msg")
 	}
 
 	// if previously Decided we do not return Decided true again
 	if prevDecided {
+		if decidedMsg != nil {
+			c.uponLateCommit(inst, decidedMsg)
+		}
 		return nil, err
 	}
 
@@ -122,13 +202,32 @@ func (c *Controller) UponExistingInstanceMsg(msg *SignedMessage) (*SignedMessage
 		return nil, nil
 	}
 
+	inst.decidedMsg = decidedMsg
 	if err := c.saveAndBroadcastDecided(decidedMsg); err != nil {
 		// no need to fail processing instance deciding if failed to save/ broadcast
-		fmt.Printf("%s\n", err.Error())
+		c.logError(err, decidedMsg)
 	}
 	return msg, nil
 }
 
+// uponLateCommit saves and broadcasts the aggregated commit of a previously decided instance if it has more signers than its decided msg.
+// Late commits are aggregated only for instances within the config's late commit window.
+func (c *Controller) uponLateCommit(inst *Instance, aggregatedCommit *SignedMessage) {
+	window := c.GetConfig().GetLateCommitWindow()
+	if window <= 0 || inst.GetHeight() <= c.Height-window {
+		return
+	}
+	if inst.decidedMsg != nil && len(aggregatedCommit.Signers) <= len(inst.decidedMsg.Signers) {
+		return
+	}
+
+	inst.decidedMsg = aggregatedCommit
+	if err := c.saveAndBroadcastDecided(aggregatedCommit); err != nil {
+		// no need to fail processing the commit if failed to save/ broadcast
+		c.logError(err, aggregatedCommit)
+	}
+}
+
 func (c *Controller) baseMsgValidation(msg *SignedMessage) error {
 	// verify msg belongs to controller
 	if !bytes.Equal(c.Identifier, msg.Message.Identifier) {
@@ -154,10 +253,28 @@ func (c *Controller) GetIdentifier() []byte {
 // addAndStoreNewInstance returns creates a new QBFT instance, stores it in an array and returns it
 func (c *Controller) addAndStoreNewInstance() *Instance {
 	i := NewInstance(c.GetConfig(), c.Share, c.Identifier, c.Height)
-	c.StoredInstances.addNewInstance(i)
+	c.storeInstance(i)
 	return i
 }
 
+// storeInstance adds the instance to StoredInstances, calling the eviction func for an evicted instance
+func (c *Controller) storeInstance(instance *Instance) {
+	instance.broadcastF = c.broadcast
+	instance.equivocationF = c.reportEquivocation
+	if evicted := c.StoredInstances.addNewInstance(instance); evicted != nil {
+		c.onInstanceEvicted(evicted)
+	}
+}
+
+func (c *Controller) onInstanceEvicted(instance *Instance) {
+	if evictionF := c.GetConfig().GetInstanceEvictionF(); evictionF != nil {
+		if err := evictionF(instance); err != nil {
+			// no need to fail storing a new instance if failed handling the evicted instance
+			instance.logError(err)
+		}
+	}
+}
+
 func (c *Controller) canStartInstance(height Height, value []byte) error {
 	if height > FirstHeight {
 		// check prev instance if prev instance is not the first instance
@@ -165,8 +282,12 @@ func (c *Controller) canStartInstance(height Height, value []byte) error {
 		if inst == nil {
 			return errors.New("could not find previous instance")
 		}
-		if decided, _ := inst.IsDecided(); !decided {
-			return errors.New("previous instance hasn't Decided")
+		if c.maxConcurrentInstances() == 1 {
+			if decided, _ := inst.IsDecided(); !decided && !inst.IsStopped() {
+				return errors.New("previous instance hasn't Decided")
+			}
+		} else if err := c.canRunConcurrentInstance(); err != nil {
+			return err
 		}
 	}
 
@@ -178,6 +299,38 @@ func (c *Controller) canStartInstance(height Height, value []byte) error {
 	return nil
 }
 
+// canRunConcurrentInstance returns error if another instance can't run alongside the running (not decided or stopped) instances
+func (c *Controller) canRunConcurrentInstance() error {
+	running := 0
+	for _, inst := range c.StoredInstances.Instances() {
+		if !inst.State.Decided && !inst.State.Stopped {
+			running++
+		}
+	}
+	if running >= c.maxConcurrentInstances() {
+		return errors.New("max concurrent instances running")
+	}
+
+	// adding a new instance ejects the oldest stored instance, it can't be ejected while running
+	if oldest := c.StoredInstances.Oldest(); c.StoredInstances.IsFull() && !oldest.State.Decided && !oldest.State.Stopped {
+		return errors.New("oldest stored instance hasn't Decided")
+	}
+	return nil
+}
+
+// maxConcurrentInstances returns the number of instances that can run concurrently, between 1 (sequential) and the stored instances capacity.
+// Instances run sequentially without a round timer per instance (see InstanceTimerF), concurrent instances would reset each other's shared timer
+func (c *Controller) maxConcurrentInstances() int {
+	max := c.GetConfig().GetMaxConcurrentInstances()
+	if max < 1 || c.GetConfig().GetInstanceTimerF() == nil {
+		return 1
+	}
+	if max > c.StoredInstances.Capacity() {
+		return c.StoredInstances.Capacity()
+	}
+	return max
+}
+
 // GetRoot returns the state's deterministic root
 func (c *Controller) GetRoot() ([]byte, error) {
 	rootStruct := struct {
@@ -190,20 +343,18 @@ func (c *Controller) GetRoot() ([]byte, error) {
 	}{
 		Identifier:             c.Identifier,
 		Height:                 c.Height,
-		InstanceRoots:          make([][]byte, len(c.StoredInstances)),
+		InstanceRoots:          make([][]byte, c.StoredInstances.Capacity()),
 		HigherReceivedMessages: c.FutureMsgsContainer,
 		Domain:                 c.Domain,
 		Share:                  c.Share,
 	}
 
-	for i, inst := range c.StoredInstances {
-		if inst != nil {
-			r, err := inst.GetRoot()
-			if err != nil {
-				return nil, errors.Wrap(err, "failed getting instance root")
-			}
-			rootStruct.InstanceRoots[i] = r
+	for i, inst := range c.StoredInstances.Instances() {
+		r, err := inst.GetRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed getting instance root")
 		}
+		rootStruct.InstanceRoots[i] = r
 	}
 
 	marshaledRoot, err := json.Marshal(rootStruct)
@@ -226,22 +377,67 @@ func (c *Controller) Decode(data []byte) error {
 		return errors.Wrap(err, "could not decode controller")
 	}
 
+	if c.StoredInstances == nil {
+		c.StoredInstances = NewInstanceContainer(HistoricalInstanceCapacity)
+	}
+
 	config := c.GetConfig()
-	for _, i := range c.StoredInstances {
-		if i != nil {
-			i.config = config
+	for _, i := range c.StoredInstances.Instances() {
+		i.config = config
+		i.broadcastF = c.broadcast
+		i.equivocationF = c.reportEquivocation
+		if i.processMsgF == nil {
+			i.processMsgF = types.NewThreadSafeF()
+		}
+	}
+	return nil
+}
+
+// checkpoint saves an encoded snapshot of the controller (and its stored instances) if a ControllerStorage is configured, then broadcasts the msgs held since the last checkpoint.
+// Msgs are broadcasted only once the state that sent them was saved, a controller restored after a crash never sends msgs conflicting with msgs it already sent.
+// If saving fails the held msgs are dropped (never broadcasted)
+func (c *Controller) checkpoint() error {
+	storage := c.GetConfig().GetControllerStorage()
+	if storage == nil {
+		return nil
+	}
+
+	pending := c.pendingBroadcasts
+	c.pendingBroadcasts = nil
+
+	byts, err := c.Encode()
+	if err != nil {
+		return errors.Wrap(err, "could not encode controller")
+	}
+	if err := storage.SaveControllerSnapshot(c.Identifier, byts); err != nil {
+		return errors.Wrap(err, "could not save controller snapshot")
+	}
+
+	var broadcastErr error
+	for _, msg := range pending {
+		if err := c.GetConfig().GetNetwork().Broadcast(msg); err != nil {
+			broadcastErr = errors.Wrap(err, "could not broadcast checkpointed msg")
 		}
 	}
+	return broadcastErr
+}
+
+// broadcast holds msg until the next checkpoint if a ControllerStorage is configured, broadcasts it to the network otherwise
+func (c *Controller) broadcast(msg *types.SSVMessage) error {
+	if c.GetConfig().GetControllerStorage() == nil {
+		return c.GetConfig().GetNetwork().Broadcast(msg)
+	}
+	c.pendingBroadcasts = append(c.pendingBroadcasts, msg)
 	return nil
 }
 
 func (c *Controller) saveAndBroadcastDecided(aggregatedCommit *SignedMessage) error {
-	if err := c.GetConfig().GetStorage().SaveHighestDecided(aggregatedCommit); err != nil {
+	if err := c.saveDecided(aggregatedCommit); err != nil {
 		return errors.Wrap(err, "could not save decided")
 	}
 
 	// Broadcast Decided msg
-	byts, err := aggregatedCommit.Encode()
+	byts, err := aggregatedCommit.EncodeWithVersion(c.GetConfig().GetEncodingVersion())
 	if err != nil {
 		return errors.Wrap(err, "could not encode decided message")
 	}
@@ -251,13 +447,33 @@ func (c *Controller) saveAndBroadcastDecided(aggregatedCommit *SignedMessage) er
 		MsgID:   ControllerIdToMessageID(c.Identifier),
 		Data:    byts,
 	}
-	if err := c.GetConfig().GetNetwork().Broadcast(msgToBroadcast); err != nil {
+	if err := c.broadcast(msgToBroadcast); err != nil {
 		// We do not return error here, just Log broadcasting error.
 		return errors.Wrap(err, "could not broadcast decided")
 	}
 	return nil
 }
 
+// saveDecided saves the decided msg as highest decided (unless a higher decided was saved) and, if storage is a HistoricalStorage, adds it to the decided history
+func (c *Controller) saveDecided(signedMsg *SignedMessage) error {
+	storage := c.GetConfig().GetStorage()
+	highest, err := storage.GetHighestDecided(signedMsg.Message.Identifier)
+	if err != nil {
+		return errors.Wrap(err, "could not get highest decided")
+	}
+	if highest == nil || highest.Message.Height <= signedMsg.Message.Height {
+		if err := storage.SaveHighestDecided(signedMsg); err != nil {
+			return errors.Wrap(err, "could not save highest decided")
+		}
+	}
+	if historical, ok := storage.(HistoricalStorage); ok {
+		if err := historical.SaveDecided(signedMsg); err != nil {
+			return errors.Wrap(err, "could not save decided history")
+		}
+	}
+	return nil
+}
+
 func (c *Controller) GetConfig() IConfig {
 	return c.config
 }
@@ -0,0 +1,112 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func concurrentTestingController(maxConcurrentInstances int) (*qbft.Controller, map[qbft.Height]*testingutils.TestQBFTTimer) {
+	ks := testingutils.Testing4SharesSet()
+	timers := make(map[qbft.Height]*testingutils.TestQBFTTimer)
+	config := testingutils.TestingConfig(ks)
+	config.MaxConcurrentInstances = maxConcurrentInstances
+	config.InstanceTimerF = func(identifier []byte, height qbft.Height) qbft.Timer {
+		timers[height] = testingutils.NewTestingTimer().(*testingutils.TestQBFTTimer)
+		return timers[height]
+	}
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	return testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config), timers
+}
+
+func decidedForHeight(c *qbft.Controller, height qbft.Height) *qbft.SignedMessage {
+	ks := testingutils.Testing4SharesSet()
+	return testingutils.MultiSignQBFTMsg(
+		[]*bls.SecretKey{ks.Shares[1], ks.Shares[2], ks.Shares[3]},
+		[]types.OperatorID{1, 2, 3},
+		&qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     height,
+			Round:      qbft.FirstRound,
+			Identifier: c.Identifier,
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		})
+}
+
+func TestController_ConcurrentInstances(t *testing.T) {
+	t.Run("sequential by default", func(t *testing.T) {
+		c, _ := concurrentTestingController(0)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.EqualError(t, c.StartNewInstance([]byte{1, 2, 3, 4}), "can't start new QBFT instance: previous instance hasn't Decided")
+	})
+
+	t.Run("sequential without instance timers", func(t *testing.T) {
+		ks := testingutils.Testing4SharesSet()
+		config := testingutils.TestingConfig(ks)
+		config.MaxConcurrentInstances = 3
+		identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+		c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.EqualError(t, c.StartNewInstance([]byte{1, 2, 3, 4}), "can't start new QBFT instance: previous instance hasn't Decided")
+	})
+
+	t.Run("bounded concurrent instances", func(t *testing.T) {
+		c, _ := concurrentTestingController(3)
+		for h := qbft.FirstHeight; h < 3; h++ {
+			require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+			require.EqualValues(t, h, c.Height)
+		}
+		require.EqualError(t, c.StartNewInstance([]byte{1, 2, 3, 4}), "can't start new QBFT instance: max concurrent instances running")
+
+		// deciding a past (running) instance doesn't decide the others
+		decided, err := c.ProcessMsg(decidedForHeight(c, 1))
+		require.NoError(t, err)
+		require.NotNil(t, decided)
+		require.True(t, c.InstanceForHeight(1).State.Decided)
+		require.False(t, c.InstanceForHeight(0).State.Decided)
+		require.False(t, c.InstanceForHeight(2).State.Decided)
+
+		// decided again is ignored
+		decided, err = c.ProcessMsg(decidedForHeight(c, 1))
+		require.NoError(t, err)
+		require.Nil(t, decided)
+
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.EqualValues(t, 3, c.Height)
+	})
+
+	t.Run("capped at capacity", func(t *testing.T) {
+		c, _ := concurrentTestingController(qbft.HistoricalInstanceCapacity + 5)
+		for h := 0; h < qbft.HistoricalInstanceCapacity; h++ {
+			require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		}
+		require.EqualError(t, c.StartNewInstance([]byte{1, 2, 3, 4}), "can't start new QBFT instance: max concurrent instances running")
+	})
+
+	t.Run("running instance not ejected", func(t *testing.T) {
+		c, _ := concurrentTestingController(2)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		for h := qbft.Height(1); h < qbft.Height(qbft.HistoricalInstanceCapacity); h++ {
+			require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+			_, err := c.ProcessMsg(decidedForHeight(c, h))
+			require.NoError(t, err)
+		}
+		require.EqualError(t, c.StartNewInstance([]byte{1, 2, 3, 4}), "can't start new QBFT instance: oldest stored instance hasn't Decided")
+	})
+
+	t.Run("independent timers", func(t *testing.T) {
+		c, timers := concurrentTestingController(2)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.Len(t, timers, 2)
+
+		require.NoError(t, c.UponRoundTimeout(0, qbft.FirstRound))
+		require.EqualValues(t, 2, c.InstanceForHeight(0).State.Round)
+		require.EqualValues(t, qbft.FirstRound, c.InstanceForHeight(1).State.Round)
+		require.EqualValues(t, 2, timers[0].State.Round)
+		require.EqualValues(t, qbft.FirstRound, timers[1].State.Round)
+	})
+}
@@ -0,0 +1,70 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestController_SSZEncodingVersion(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	controllers := make([]*qbft.Controller, 0)
+	networks := make([]*testingutils.TestingNetwork, 0)
+	for id := types.OperatorID(1); id <= 4; id++ {
+		config := testingutils.TestingConfig(ks)
+		config.EncodingVersion = qbft.SSZEncodingVersion
+		config.SigningPK = ks.Shares[id].GetPublicKey().Serialize()
+		share := testingutils.TestingShare(ks)
+		share.OperatorID = id
+		share.SharePubKey = ks.Shares[id].GetPublicKey().Serialize()
+		controllers = append(controllers, testingutils.NewTestingQBFTController(identifier[:], share, config))
+		networks = append(networks, config.Network.(*testingutils.TestingNetwork))
+	}
+
+	t.Run("decides with ssz encoded msgs", func(t *testing.T) {
+		for _, c := range controllers {
+			require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		}
+
+		// deliver every broadcasted msg to all controllers (including its sender) until none is broadcasted
+		delivered := make([]int, len(networks))
+		for progress := true; progress; {
+			progress = false
+			for i, net := range networks {
+				for ; delivered[i] < len(net.BroadcastedMsgs); delivered[i]++ {
+					progress = true
+					byts := net.BroadcastedMsgs[delivered[i]].Data
+					require.NotEqualValues(t, '{', byts[0])
+
+					msg := &qbft.SignedMessage{}
+					require.NoError(t, msg.DecodeWithVersion(qbft.SSZEncodingVersion, byts))
+					for _, c := range controllers {
+						_, _ = c.ProcessMsg(msg)
+					}
+				}
+			}
+		}
+
+		for _, c := range controllers {
+			decided, value := c.InstanceForHeight(qbft.FirstHeight).IsDecided()
+			require.True(t, decided)
+			require.EqualValues(t, []byte{1, 2, 3, 4}, value)
+		}
+	})
+
+	t.Run("json signed msgs rejected", func(t *testing.T) {
+		c := controllers[1]
+		msg := testingutils.SignQBFTMsg(ks.Shares[1], 1, &qbft.Message{
+			MsgType:    qbft.ProposalMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      2,
+			Identifier: identifier[:],
+			Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil),
+		})
+		_, err := c.ProcessMsg(msg)
+		require.EqualError(t, err, "could not process msg: proposal invalid: proposal msg signature invalid: failed to verify signature")
+	})
+}
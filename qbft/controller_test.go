@@ -1,16 +1,20 @@
 package qbft
 
 import (
+	"encoding/json"
+	"github.com/bloxapp/ssv-spec/types"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestInstances_FindInstance(t *testing.T) {
-	i := InstanceContainer{
+	i := NewInstanceContainer(
+		HistoricalInstanceCapacity,
 		&Instance{State: &State{Height: 1}},
 		&Instance{State: &State{Height: 2}},
 		&Instance{State: &State{Height: 3}},
-	}
+	)
 
 	t.Run("find 1", func(t *testing.T) {
 		require.NotNil(t, i.FindInstance(1))
@@ -23,51 +27,108 @@ func TestInstances_FindInstance(t *testing.T) {
 	})
 }
 
+func heightsOf(instances []*Instance) []Height {
+	ret := make([]Height, 0)
+	for _, inst := range instances {
+		ret = append(ret, inst.State.Height)
+	}
+	return ret
+}
+
 func TestInstances_addNewInstance(t *testing.T) {
 	t.Run("add to full", func(t *testing.T) {
-		i := InstanceContainer{
+		i := NewInstanceContainer(
+			HistoricalInstanceCapacity,
 			&Instance{State: &State{Height: 1}},
 			&Instance{State: &State{Height: 2}},
 			&Instance{State: &State{Height: 3}},
 			&Instance{State: &State{Height: 4}},
 			&Instance{State: &State{Height: 5}},
-		}
-		i.addNewInstance(&Instance{State: &State{Height: 6}})
+		)
+		require.True(t, i.IsFull())
+		evicted := i.addNewInstance(&Instance{State: &State{Height: 6}})
 
-		require.EqualValues(t, 6, i[0].State.Height)
-		require.EqualValues(t, 1, i[1].State.Height)
-		require.EqualValues(t, 2, i[2].State.Height)
-		require.EqualValues(t, 3, i[3].State.Height)
-		require.EqualValues(t, 4, i[4].State.Height)
+		require.EqualValues(t, 5, evicted.State.Height)
+		require.EqualValues(t, []Height{6, 1, 2, 3, 4}, heightsOf(i.Instances()))
+		require.EqualValues(t, 4, i.Oldest().State.Height)
 	})
 
 	t.Run("add to empty", func(t *testing.T) {
-		i := InstanceContainer{}
-		i.addNewInstance(&Instance{State: &State{Height: 1}})
+		i := NewInstanceContainer(HistoricalInstanceCapacity)
+		require.Nil(t, i.Oldest())
+		require.Nil(t, i.addNewInstance(&Instance{State: &State{Height: 1}}))
 
-		require.EqualValues(t, 1, i[0].State.Height)
-		require.Nil(t, i[1])
-		require.Nil(t, i[2])
-		require.Nil(t, i[3])
-		require.Nil(t, i[4])
+		require.EqualValues(t, []Height{1}, heightsOf(i.Instances()))
+		require.EqualValues(t, 1, i.Len())
+		require.False(t, i.IsFull())
 	})
 
 	t.Run("add to semi full", func(t *testing.T) {
-		i := InstanceContainer{
+		i := NewInstanceContainer(
+			HistoricalInstanceCapacity,
 			&Instance{State: &State{Height: 1}},
 			&Instance{State: &State{Height: 2}},
 			&Instance{State: &State{Height: 3}},
-		}
-		i.addNewInstance(&Instance{State: &State{Height: 4}})
+		)
+		require.Nil(t, i.addNewInstance(&Instance{State: &State{Height: 4}}))
 
-		require.EqualValues(t, 4, i[0].State.Height)
-		require.EqualValues(t, 1, i[1].State.Height)
-		require.EqualValues(t, 2, i[2].State.Height)
-		require.EqualValues(t, 3, i[3].State.Height)
-		require.Nil(t, i[4])
+		require.EqualValues(t, []Height{4, 1, 2, 3}, heightsOf(i.Instances()))
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		i := &InstanceContainer{}
+		require.Nil(t, i.addNewInstance(&Instance{State: &State{Height: 1}}))
+		require.EqualValues(t, HistoricalInstanceCapacity, i.Capacity())
+	})
+
+	t.Run("wrap around", func(t *testing.T) {
+		i := NewInstanceContainer(2)
+		for h := Height(0); h < 7; h++ {
+			i.addNewInstance(&Instance{State: &State{Height: h}})
+		}
+		require.EqualValues(t, []Height{6, 5}, heightsOf(i.Instances()))
+		require.EqualValues(t, 5, i.Oldest().State.Height)
 	})
 }
 
+func TestInstances_resize(t *testing.T) {
+	i := NewInstanceContainer(
+		HistoricalInstanceCapacity,
+		&Instance{State: &State{Height: 4}},
+		&Instance{State: &State{Height: 3}},
+		&Instance{State: &State{Height: 2}},
+		&Instance{State: &State{Height: 1}},
+	)
+
+	evicted := i.resize(2)
+	require.EqualValues(t, []Height{1, 2}, heightsOf(evicted))
+	require.EqualValues(t, []Height{4, 3}, heightsOf(i.Instances()))
+
+	evicted = i.resize(10)
+	require.Len(t, evicted, 0)
+	require.EqualValues(t, 10, i.Capacity())
+	require.EqualValues(t, []Height{4, 3}, heightsOf(i.Instances()))
+}
+
+func TestInstances_Marshaling(t *testing.T) {
+	i := NewInstanceContainer(3, testingInstanceStruct)
+
+	byts, err := json.Marshal(i)
+	require.NoError(t, err)
+
+	// encoded as an array of capacity instances, newest first
+	arr := make([]*Instance, 0)
+	require.NoError(t, json.Unmarshal(byts, &arr))
+	require.Len(t, arr, 3)
+	require.NotNil(t, arr[0])
+	require.Nil(t, arr[1])
+
+	decoded := &InstanceContainer{}
+	require.NoError(t, json.Unmarshal(byts, decoded))
+	require.EqualValues(t, 3, decoded.Capacity())
+	require.EqualValues(t, 1, decoded.Len())
+}
+
 func TestController_Marshaling(t *testing.T) {
 	c := testingControllerStruct
 
@@ -81,3 +142,192 @@ func TestController_Marshaling(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, byts, bytsDecoded)
 }
+
+type testingControllerStorage map[string][]byte
+
+func (s testingControllerStorage) SaveControllerSnapshot(identifier []byte, data []byte) error {
+	s[string(identifier)] = data
+	return nil
+}
+
+func (s testingControllerStorage) GetControllerSnapshot(identifier []byte) ([]byte, error) {
+	return s[string(identifier)], nil
+}
+
+type testingTimer struct {
+	rounds   []Round
+	timeouts []time.Duration
+}
+
+func (t *testingTimer) TimeoutForRound(round Round, timeout time.Duration) {
+	t.rounds = append(t.rounds, round)
+	t.timeouts = append(t.timeouts, timeout)
+}
+
+func TestNewControllerFromStorage(t *testing.T) {
+	t.Run("no storage", func(t *testing.T) {
+		_, err := NewControllerFromStorage([]byte{1, 2, 3, 4}, testingShare, types.PrimusTestnet, &Config{})
+		require.EqualError(t, err, "controller storage not configured")
+	})
+
+	t.Run("no snapshot", func(t *testing.T) {
+		timer := &testingTimer{}
+		config := &Config{Timer: timer, ControllerStorage: testingControllerStorage{}}
+
+		c, err := NewControllerFromStorage([]byte{1, 2, 3, 4}, testingShare, types.PrimusTestnet, config)
+		require.NoError(t, err)
+		require.EqualValues(t, -1, c.Height)
+		require.Len(t, timer.rounds, 0)
+	})
+
+	t.Run("restore running instance", func(t *testing.T) {
+		storage := testingControllerStorage{}
+		timer := &testingTimer{}
+		config := &Config{Timer: timer, ControllerStorage: storage}
+
+		c := NewController([]byte{1, 2, 3, 4}, testingShare, types.PrimusTestnet, config)
+		c.Height = testingControllerStruct.Height
+		c.StoredInstances = testingControllerStruct.StoredInstances
+		require.NoError(t, c.checkpoint())
+
+		restored, err := NewControllerFromStorage([]byte{1, 2, 3, 4}, testingShare, types.PrimusTestnet, config)
+		require.NoError(t, err)
+
+		r1, err := c.GetRoot()
+		require.NoError(t, err)
+		r2, err := restored.GetRoot()
+		require.NoError(t, err)
+		require.EqualValues(t, r1, r2)
+
+		inst := restored.InstanceForHeight(restored.Height)
+		require.NotNil(t, inst)
+		require.NotNil(t, inst.GetConfig())
+		require.NotNil(t, inst.processMsgF)
+		require.EqualValues(t, []Round{inst.State.Round}, timer.rounds)
+	})
+
+	t.Run("restore stopped instance", func(t *testing.T) {
+		storage := testingControllerStorage{}
+		timer := &testingTimer{}
+		config := &Config{Timer: timer, ControllerStorage: storage}
+
+		c := NewController([]byte{1, 2, 3, 4}, testingShare, types.PrimusTestnet, config)
+		c.bumpHeight()
+		c.addAndStoreNewInstance().Stop()
+		require.NoError(t, c.checkpoint())
+
+		restored, err := NewControllerFromStorage([]byte{1, 2, 3, 4}, testingShare, types.PrimusTestnet, config)
+		require.NoError(t, err)
+		require.True(t, restored.InstanceForHeight(FirstHeight).IsStopped())
+		require.Len(t, timer.rounds, 0)
+	})
+
+	t.Run("snapshot for different identifier", func(t *testing.T) {
+		storage := testingControllerStorage{}
+		config := &Config{Timer: &testingTimer{}, ControllerStorage: storage}
+
+		c := NewController([]byte{1, 2, 3, 5}, testingShare, types.PrimusTestnet, config)
+		byts, err := c.Encode()
+		require.NoError(t, err)
+		require.NoError(t, storage.SaveControllerSnapshot([]byte{1, 2, 3, 4}, byts))
+
+		_, err = NewControllerFromStorage([]byte{1, 2, 3, 4}, testingShare, types.PrimusTestnet, config)
+		require.EqualError(t, err, "controller snapshot doesn't belong to Identifier")
+	})
+}
+
+func TestController_InstanceEviction(t *testing.T) {
+	evicted := make([]Height, 0)
+	config := &Config{
+		Timer:                      &testingTimer{},
+		HistoricalInstanceCapacity: 2,
+		InstanceEvictionF: func(instance *Instance) error {
+			evicted = append(evicted, instance.State.Height)
+			return nil
+		},
+	}
+
+	c := NewController([]byte{1, 2, 3, 4}, testingShare, types.PrimusTestnet, config)
+	require.EqualValues(t, 2, c.StoredInstances.Capacity())
+	for h := FirstHeight; h < 4; h++ {
+		c.storeInstance(NewInstance(config, testingShare, c.Identifier, h))
+	}
+	require.EqualValues(t, []Height{0, 1}, evicted)
+	require.EqualValues(t, []Height{3, 2}, heightsOf(c.StoredInstances.Instances()))
+
+	t.Run("restore with smaller capacity", func(t *testing.T) {
+		evicted = make([]Height, 0)
+		storage := testingControllerStorage{}
+		config.ControllerStorage = storage
+		require.NoError(t, c.checkpoint())
+
+		config.HistoricalInstanceCapacity = 1
+		restored, err := NewControllerFromStorage(c.Identifier, testingShare, types.PrimusTestnet, config)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, restored.StoredInstances.Capacity())
+		require.EqualValues(t, []Height{3}, heightsOf(restored.StoredInstances.Instances()))
+		require.EqualValues(t, []Height{2}, evicted)
+	})
+}
+
+type testingHistoricalStorage struct {
+	decided []*SignedMessage
+	err     error
+}
+
+func (s *testingHistoricalStorage) SaveHighestDecided(signedMsg *SignedMessage) error {
+	return nil
+}
+
+func (s *testingHistoricalStorage) GetHighestDecided(identifier []byte) (*SignedMessage, error) {
+	return nil, nil
+}
+
+func (s *testingHistoricalStorage) SaveDecided(signedMsg *SignedMessage) error {
+	s.decided = append(s.decided, signedMsg)
+	return nil
+}
+
+func (s *testingHistoricalStorage) GetDecided(identifier []byte, from Height, to Height) ([]*SignedMessage, error) {
+	return s.decided, s.err
+}
+
+func TestSaveDecidedOnEviction(t *testing.T) {
+	storage := &testingHistoricalStorage{}
+	evictionF := SaveDecidedOnEviction(storage)
+
+	inst := NewInstance(&Config{}, testingShare, []byte{1, 2, 3, 4}, 1)
+	commitData, err := (&CommitData{Data: []byte{1, 2, 3, 4}}).Encode()
+	require.NoError(t, err)
+	for _, id := range []types.OperatorID{1, 2, 3} {
+		_, err := inst.State.CommitContainer.AddFirstMsgForSignerAndRound(SignMsg(TestingSK, id, &Message{
+			MsgType:    CommitMsgType,
+			Height:     1,
+			Round:      FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       commitData,
+		}))
+		require.NoError(t, err)
+	}
+
+	t.Run("not decided", func(t *testing.T) {
+		require.NoError(t, evictionF(inst))
+		require.Len(t, storage.decided, 0)
+	})
+
+	t.Run("decided", func(t *testing.T) {
+		inst.State.Decided = true
+		inst.State.DecidedValue = []byte{1, 2, 3, 4}
+		require.NoError(t, evictionF(inst))
+		require.Len(t, storage.decided, 1)
+		require.EqualValues(t, []types.OperatorID{1, 2, 3}, storage.decided[0].Signers)
+	})
+
+	t.Run("decided without commits", func(t *testing.T) {
+		decided := NewInstance(&Config{}, testingShare, []byte{1, 2, 3, 4}, 2)
+		decided.State.Decided = true
+		decided.State.DecidedValue = []byte{1, 2, 3, 4}
+		require.NoError(t, evictionF(decided))
+		require.Len(t, storage.decided, 1)
+	})
+}
@@ -1,7 +1,6 @@
 package qbft
 
 import (
-	"fmt"
 	"github.com/bloxapp/ssv-spec/types"
 	"github.com/pkg/errors"
 )
@@ -9,7 +8,8 @@ import (
 // UponDecided returns decided msg if decided, nil otherwise
 func (c *Controller) UponDecided(msg *SignedMessage) (*SignedMessage, error) {
 	// decided msgs for past (already decided) instances will not decide again, just return
-	if msg.Message.Height < c.Height {
+	// (with concurrent instances a past instance might still be running)
+	if msg.Message.Height < c.Height && !c.isConcurrentlyRunning(msg.Message.Height) {
 		return nil, nil
 	}
 
@@ -20,6 +20,14 @@ func (c *Controller) UponDecided(msg *SignedMessage) (*SignedMessage, error) {
 	); err != nil {
 		return nil, errors.Wrap(err, "invalid decided msg")
 	}
+	return c.uponValidDecided(msg)
+}
+
+// uponValidDecided is UponDecided for a msg already validated with validateDecided, returns decided msg if decided, nil otherwise
+func (c *Controller) uponValidDecided(msg *SignedMessage) (*SignedMessage, error) {
+	if msg.Message.Height < c.Height && !c.isConcurrentlyRunning(msg.Message.Height) {
+		return nil, nil
+	}
 
 	// get decided value
 	data, err := msg.Message.GetCommitData()
@@ -31,12 +39,18 @@ func (c *Controller) UponDecided(msg *SignedMessage) (*SignedMessage, error) {
 	inst := c.InstanceForHeight(msg.Message.Height)
 	prevDecided := inst != nil && inst.State.Decided
 
-	// Mark current instance decided
-	if inst := c.InstanceForHeight(c.Height); inst != nil && !inst.State.Decided {
+	// Mark current instance decided, with concurrent instances only the msg's instance is decided
+	markedHeight := c.Height
+	if c.maxConcurrentInstances() > 1 {
+		markedHeight = msg.Message.Height
+	}
+	if inst := c.InstanceForHeight(markedHeight); inst != nil && !inst.State.Decided {
 		inst.State.Decided = true
-		if c.Height == msg.Message.Height {
+		if markedHeight == msg.Message.Height {
 			inst.State.Round = msg.Message.Round
 			inst.State.DecidedValue = data.Data
+			inst.decidedMsg = msg
+			inst.emit(DecidedEvent, msg, data.Data, nil)
 		}
 	}
 
@@ -47,22 +61,33 @@ func (c *Controller) UponDecided(msg *SignedMessage) (*SignedMessage, error) {
 		i.State.Round = msg.Message.Round
 		i.State.Decided = true
 		i.State.DecidedValue = data.Data
-		c.StoredInstances.addNewInstance(i)
+		i.decidedMsg = msg
+		c.storeInstance(i)
+		i.emit(DecidedEvent, msg, data.Data, nil)
 
 		// bump height
 		c.Height = msg.Message.Height
 	}
 
 	if !prevDecided {
-		if err := c.GetConfig().GetStorage().SaveHighestDecided(msg); err != nil {
+		if err := c.saveDecided(msg); err != nil {
 			// no need to fail processing the decided msg if failed to save
-			fmt.Printf("%s\n", err.Error())
+			c.logError(err, msg)
 		}
 		return msg, nil
 	}
 	return nil, nil
 }
 
+// isConcurrentlyRunning returns true if concurrent instances are enabled and the instance for height is running (not decided)
+func (c *Controller) isConcurrentlyRunning(height Height) bool {
+	if c.maxConcurrentInstances() == 1 {
+		return false
+	}
+	inst := c.InstanceForHeight(height)
+	return inst != nil && !inst.State.Decided
+}
+
 func validateDecided(
 	config IConfig,
 	signedDecided *SignedMessage,
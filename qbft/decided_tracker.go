@@ -0,0 +1,97 @@
+package qbft
+
+import (
+	"encoding/hex"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"sync"
+)
+
+// DecidedTracker verifies decided msgs against a committee and tracks the highest decided msg per identifier.
+// Unlike Controller it needs no signer, timer or network nor an OperatorID in the share, for nodes observing (not members of) a committee, e.g. exporters.
+type DecidedTracker struct {
+	// Share holds the committee and quorum decided msgs are verified against, OperatorID is ignored
+	Share  *types.Share
+	config IConfig
+
+	lock        sync.RWMutex
+	highest     map[string]*SignedMessage
+	subscribers map[int]chan *SignedMessage
+	nextSubID   int
+}
+
+// NewDecidedTracker returns a tracker for the share's committee, verifying decided msgs signed for domain
+func NewDecidedTracker(share *types.Share, domain types.DomainType) *DecidedTracker {
+	return &DecidedTracker{
+		Share:       share,
+		config:      &Config{Domain: domain},
+		highest:     make(map[string]*SignedMessage),
+		subscribers: make(map[int]chan *SignedMessage),
+	}
+}
+
+// ProcessDecided validates a decided msg and tracks it if it's higher than the identifier's highest decided msg, or for the same height with more signers.
+// Returns true and passes msg to all subscribers if tracked
+func (t *DecidedTracker) ProcessDecided(msg *SignedMessage) (bool, error) {
+	if msg == nil || msg.Message == nil {
+		return false, errors.New("invalid decided msg: msg is nil")
+	}
+	if !types.ValidatorPK(t.Share.ValidatorPubKey).MessageIDBelongs(types.MessageIDFromBytes(msg.Message.Identifier)) {
+		return false, errors.New("decided msg doesn't belong to the share's validator")
+	}
+	if err := validateDecided(t.config, msg, t.Share); err != nil {
+		return false, errors.Wrap(err, "invalid decided msg")
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := hex.EncodeToString(msg.Message.Identifier)
+	if highest, found := t.highest[key]; found {
+		if msg.Message.Height < highest.Message.Height {
+			return false, nil
+		}
+		if msg.Message.Height == highest.Message.Height && len(msg.Signers) <= len(highest.Signers) {
+			return false, nil
+		}
+	}
+	t.highest[key] = msg
+
+	for _, sub := range t.subscribers {
+		select {
+		case sub <- msg:
+		default: // slow subscriber, can always get the highest decided msg with GetHighestDecided
+		}
+	}
+	return true, nil
+}
+
+// GetHighestDecided returns the identifier's highest decided msg, nil if none was tracked
+func (t *DecidedTracker) GetHighestDecided(identifier []byte) *SignedMessage {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.highest[hex.EncodeToString(identifier)]
+}
+
+// Subscribe returns a stream of tracked decided msgs (for all identifiers) and a func closing it.
+// Tracked msgs are dropped for a subscriber whose channel is full (capacity unread msgs) instead of blocking processing
+func (t *DecidedTracker) Subscribe(capacity int) (<-chan *SignedMessage, func()) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	id := t.nextSubID
+	t.nextSubID++
+	sub := make(chan *SignedMessage, capacity)
+	t.subscribers[id] = sub
+
+	return sub, func() {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+
+		if _, found := t.subscribers[id]; found {
+			delete(t.subscribers, id)
+			close(sub)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDecidedTracker(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	// an observer's share, not a committee member
+	share := &types.Share{
+		ValidatorPubKey: ks.ValidatorPK.Serialize(),
+		Quorum:          ks.Threshold,
+		PartialQuorum:   ks.PartialThreshold,
+		Committee:       ks.Committee(),
+	}
+	decided := func(height qbft.Height, ids ...types.OperatorID) *qbft.SignedMessage {
+		sks := make([]*bls.SecretKey, 0)
+		for _, id := range ids {
+			sks = append(sks, ks.Shares[id])
+		}
+		return testingutils.MultiSignQBFTMsg(sks, ids, &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     height,
+			Round:      qbft.FirstRound,
+			Identifier: identifier[:],
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		})
+	}
+
+	t.Run("tracks highest decided", func(t *testing.T) {
+		tracker := qbft.NewDecidedTracker(share, types.PrimusTestnet)
+		require.Nil(t, tracker.GetHighestDecided(identifier[:]))
+
+		tracked, err := tracker.ProcessDecided(decided(2, 1, 2, 3))
+		require.NoError(t, err)
+		require.True(t, tracked)
+		require.EqualValues(t, 2, tracker.GetHighestDecided(identifier[:]).Message.Height)
+
+		// lower height
+		tracked, err = tracker.ProcessDecided(decided(1, 1, 2, 3))
+		require.NoError(t, err)
+		require.False(t, tracked)
+
+		// same height, same signers count
+		tracked, err = tracker.ProcessDecided(decided(2, 2, 3, 4))
+		require.NoError(t, err)
+		require.False(t, tracked)
+
+		// same height, more signers
+		tracked, err = tracker.ProcessDecided(decided(2, 1, 2, 3, 4))
+		require.NoError(t, err)
+		require.True(t, tracked)
+		require.Len(t, tracker.GetHighestDecided(identifier[:]).Signers, 4)
+
+		tracked, err = tracker.ProcessDecided(decided(3, 1, 2, 3))
+		require.NoError(t, err)
+		require.True(t, tracked)
+		require.EqualValues(t, 3, tracker.GetHighestDecided(identifier[:]).Message.Height)
+	})
+
+	t.Run("invalid decided", func(t *testing.T) {
+		tracker := qbft.NewDecidedTracker(share, types.PrimusTestnet)
+
+		_, err := tracker.ProcessDecided(decided(1, 1, 2))
+		require.EqualError(t, err, "invalid decided msg: not a decided msg")
+
+		msg := decided(1, 1, 2, 3)
+		msg.Signature = decided(1, 1, 2, 4).Signature
+		_, err = tracker.ProcessDecided(msg)
+		require.EqualError(t, err, "invalid decided msg: invalid decided msg: commit msg signature invalid: failed to verify signature")
+
+		other := types.NewMsgID(make([]byte, 48), types.BNRoleAttester)
+		msg = decided(1, 1, 2, 3)
+		msg.Message.Identifier = other[:]
+		_, err = tracker.ProcessDecided(msg)
+		require.EqualError(t, err, "decided msg doesn't belong to the share's validator")
+
+		require.Nil(t, tracker.GetHighestDecided(identifier[:]))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		tracker := qbft.NewDecidedTracker(share, types.PrimusTestnet)
+		stream, unsubscribe := tracker.Subscribe(1)
+
+		_, err := tracker.ProcessDecided(decided(1, 1, 2, 3))
+		require.NoError(t, err)
+		_, err = tracker.ProcessDecided(decided(2, 1, 2, 3)) // dropped, stream is full
+		require.NoError(t, err)
+
+		msg := <-stream
+		require.EqualValues(t, 1, msg.Message.Height)
+		require.EqualValues(t, 2, tracker.GetHighestDecided(identifier[:]).Message.Height)
+
+		unsubscribe()
+		unsubscribe() // no-op
+		_, open := <-stream
+		require.False(t, open)
+		_, err = tracker.ProcessDecided(decided(3, 1, 2, 3))
+		require.NoError(t, err)
+	})
+}
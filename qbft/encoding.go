@@ -0,0 +1,446 @@
+package qbft
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+// EncodingVersion determines how msgs and msg data are encoded and how their roots are calculated
+type EncodingVersion byte
+
+const (
+	// JSONEncodingVersion encodes with JSON, root is the sha256 of the encoded bytes
+	JSONEncodingVersion EncodingVersion = iota
+	// SSZEncodingVersion encodes with SSZ, root is the SSZ hash tree root
+	SSZEncodingVersion
+)
+
+// sszEncoder is implemented by all fastssz generated types
+type sszEncoder interface {
+	MarshalSSZ() ([]byte, error)
+	UnmarshalSSZ(buf []byte) error
+	HashTreeRoot() ([32]byte, error)
+}
+
+// encodeWithVersion encodes obj with JSON or its SSZ representation according to version
+func encodeWithVersion(version EncodingVersion, obj interface{}, sszF func() (sszEncoder, error)) ([]byte, error) {
+	switch version {
+	case JSONEncodingVersion:
+		return json.Marshal(obj)
+	case SSZEncodingVersion:
+		s, err := sszF()
+		if err != nil {
+			return nil, err
+		}
+		return s.MarshalSSZ()
+	default:
+		return nil, errors.New("unknown encoding version")
+	}
+}
+
+// rootWithVersion returns the root of obj according to version
+func rootWithVersion(version EncodingVersion, obj interface{}, sszF func() (sszEncoder, error)) ([]byte, error) {
+	switch version {
+	case JSONEncodingVersion:
+		marshaledRoot, err := json.Marshal(obj)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode")
+		}
+		ret := sha256.Sum256(marshaledRoot)
+		return ret[:], nil
+	case SSZEncodingVersion:
+		s, err := sszF()
+		if err != nil {
+			return nil, err
+		}
+		ret, err := s.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash tree root")
+		}
+		return ret[:], nil
+	default:
+		return nil, errors.New("unknown encoding version")
+	}
+}
+
+// detectEncodingVersion returns the version data (an encoded signed msg or msg data) was encoded with.
+// JSON encoded data starts with '{' which SSZ encoded data never does: msg data starts with its first (little endian) offset and a signed msg with its compressed BLS signature (compression flag set)
+func detectEncodingVersion(data []byte) EncodingVersion {
+	if len(data) > 0 && data[0] != '{' {
+		return SSZEncodingVersion
+	}
+	return JSONEncodingVersion
+}
+
+// versionedMessage is a Message whose root is calculated with an encoding version, used to sign msgs with the config's EncodingVersion
+type versionedMessage struct {
+	*Message
+	version EncodingVersion
+}
+
+// GetRoot returns the msg's root according to its encoding version
+func (m *versionedMessage) GetRoot() ([]byte, error) {
+	return m.Message.GetRootWithVersion(m.version)
+}
+
+// withVersion returns msg as a Root calculated with version
+func (msg *Message) withVersion(version EncodingVersion) types.Root {
+	return &versionedMessage{Message: msg, version: version}
+}
+
+// versionedSignedMessage is a SignedMessage whose root is calculated with an encoding version, used to verify msgs with the config's EncodingVersion
+type versionedSignedMessage struct {
+	*SignedMessage
+	version EncodingVersion
+}
+
+// GetRoot returns the msg's root according to its encoding version
+func (m *versionedSignedMessage) GetRoot() ([]byte, error) {
+	return m.SignedMessage.GetRootWithVersion(m.version)
+}
+
+// withVersion returns signedMsg as a MessageSignature with a root calculated with version
+func (signedMsg *SignedMessage) withVersion(version EncodingVersion) types.MessageSignature {
+	return &versionedSignedMessage{SignedMessage: signedMsg, version: version}
+}
+
+// toSSZ returns the SSZ representation of msg
+func (msg *Message) toSSZ() (*MessageSSZ, error) {
+	if msg.MsgType < 0 {
+		return nil, errors.New("message type is invalid")
+	}
+	if msg.Height < FirstHeight {
+		return nil, errors.New("message height is invalid")
+	}
+	return &MessageSSZ{
+		MsgType:    uint64(msg.MsgType),
+		Height:     uint64(msg.Height),
+		Round:      uint64(msg.Round),
+		Identifier: msg.Identifier,
+		Data:       msg.Data,
+	}, nil
+}
+
+// fromSSZ sets msg from its SSZ representation
+func (msg *Message) fromSSZ(s *MessageSSZ) {
+	msg.MsgType = MessageType(s.MsgType)
+	msg.Height = Height(s.Height)
+	msg.Round = Round(s.Round)
+	msg.Identifier = s.Identifier
+	msg.Data = s.Data
+}
+
+// EncodeWithVersion returns a msg encoded bytes according to version or error
+func (msg *Message) EncodeWithVersion(version EncodingVersion) ([]byte, error) {
+	return encodeWithVersion(version, msg, func() (sszEncoder, error) {
+		return msg.toSSZ()
+	})
+}
+
+// DecodeWithVersion returns error if decoding data encoded with version failed
+func (msg *Message) DecodeWithVersion(version EncodingVersion, data []byte) error {
+	if version == JSONEncodingVersion {
+		return msg.Decode(data)
+	}
+	if version != SSZEncodingVersion {
+		return errors.New("unknown encoding version")
+	}
+	s := &MessageSSZ{}
+	if err := s.UnmarshalSSZ(data); err != nil {
+		return errors.Wrap(err, "could not decode ssz message")
+	}
+	msg.fromSSZ(s)
+	return nil
+}
+
+// GetRootWithVersion returns the root used for signing and verification according to version
+func (msg *Message) GetRootWithVersion(version EncodingVersion) ([]byte, error) {
+	return rootWithVersion(version, msg, func() (sszEncoder, error) {
+		return msg.toSSZ()
+	})
+}
+
+// sszFields returns signedMsg's signature, signers and the SSZ representation of its msg, shared by all SSZ representations of a SignedMessage
+func (signedMsg *SignedMessage) sszFields() ([]byte, []uint64, *MessageSSZ, error) {
+	if signedMsg.Message == nil {
+		return nil, nil, nil, errors.New("message is nil")
+	}
+	msg, err := signedMsg.Message.toSSZ()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	signers := make([]uint64, len(signedMsg.Signers))
+	for i, signer := range signedMsg.Signers {
+		signers[i] = uint64(signer)
+	}
+	return signedMsg.Signature, signers, msg, nil
+}
+
+// fromSSZFields sets signedMsg from the fields shared by all its SSZ representations
+func (signedMsg *SignedMessage) fromSSZFields(signature []byte, signers []uint64, msg *MessageSSZ) {
+	signedMsg.Signature = signature
+	signedMsg.Signers = make([]types.OperatorID, len(signers))
+	for i, signer := range signers {
+		signedMsg.Signers[i] = types.OperatorID(signer)
+	}
+	signedMsg.Message = &Message{}
+	if msg != nil {
+		signedMsg.Message.fromSSZ(msg)
+	}
+}
+
+// toSSZ returns the SSZ representation of signedMsg
+func (signedMsg *SignedMessage) toSSZ() (*SignedMessageSSZ, error) {
+	signature, signers, msg, err := signedMsg.sszFields()
+	if err != nil {
+		return nil, err
+	}
+	return &SignedMessageSSZ{
+		Signature: signature,
+		Signers:   signers,
+		Message:   msg,
+	}, nil
+}
+
+// fromSSZ sets signedMsg from its SSZ representation
+func (signedMsg *SignedMessage) fromSSZ(s *SignedMessageSSZ) {
+	signedMsg.fromSSZFields(s.Signature, s.Signers, s.Message)
+}
+
+// EncodeWithVersion returns a msg encoded bytes according to version or error
+func (signedMsg *SignedMessage) EncodeWithVersion(version EncodingVersion) ([]byte, error) {
+	return encodeWithVersion(version, signedMsg, func() (sszEncoder, error) {
+		return signedMsg.toSSZ()
+	})
+}
+
+// DecodeWithVersion returns error if decoding data encoded with version failed
+func (signedMsg *SignedMessage) DecodeWithVersion(version EncodingVersion, data []byte) error {
+	if version == JSONEncodingVersion {
+		return json.Unmarshal(data, &signedMsg)
+	}
+	if version != SSZEncodingVersion {
+		return errors.New("unknown encoding version")
+	}
+	s := &SignedMessageSSZ{}
+	if err := s.UnmarshalSSZ(data); err != nil {
+		return errors.Wrap(err, "could not decode ssz signed message")
+	}
+	signedMsg.fromSSZ(s)
+	return nil
+}
+
+// GetRootWithVersion returns the root used for signing and verification according to version
+func (signedMsg *SignedMessage) GetRootWithVersion(version EncodingVersion) ([]byte, error) {
+	return signedMsg.Message.GetRootWithVersion(version)
+}
+
+// prepareJustificationsToSSZ returns the SSZ representation of prepare justifications
+func prepareJustificationsToSSZ(msgs []*SignedMessage) ([]*PrepareJustificationSSZ, error) {
+	ret := make([]*PrepareJustificationSSZ, len(msgs))
+	for i, msg := range msgs {
+		signature, signers, m, err := msg.sszFields()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert justification")
+		}
+		ret[i] = &PrepareJustificationSSZ{
+			Signature: signature,
+			Signers:   signers,
+			Message:   (*PrepareJustificationMessageSSZ)(m),
+		}
+	}
+	return ret, nil
+}
+
+// prepareJustificationsFromSSZ returns the prepare justifications of their SSZ representation
+func prepareJustificationsFromSSZ(msgs []*PrepareJustificationSSZ) []*SignedMessage {
+	ret := make([]*SignedMessage, len(msgs))
+	for i, s := range msgs {
+		ret[i] = &SignedMessage{}
+		ret[i].fromSSZFields(s.Signature, s.Signers, (*MessageSSZ)(s.Message))
+	}
+	return ret
+}
+
+// roundChangeJustificationsToSSZ returns the SSZ representation of round change justifications
+func roundChangeJustificationsToSSZ(msgs []*SignedMessage) ([]*RoundChangeJustificationSSZ, error) {
+	ret := make([]*RoundChangeJustificationSSZ, len(msgs))
+	for i, msg := range msgs {
+		signature, signers, m, err := msg.sszFields()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert justification")
+		}
+		ret[i] = &RoundChangeJustificationSSZ{
+			Signature: signature,
+			Signers:   signers,
+			Message:   (*RoundChangeJustificationMessageSSZ)(m),
+		}
+	}
+	return ret, nil
+}
+
+// roundChangeJustificationsFromSSZ returns the round change justifications of their SSZ representation
+func roundChangeJustificationsFromSSZ(msgs []*RoundChangeJustificationSSZ) []*SignedMessage {
+	ret := make([]*SignedMessage, len(msgs))
+	for i, s := range msgs {
+		ret[i] = &SignedMessage{}
+		ret[i].fromSSZFields(s.Signature, s.Signers, (*MessageSSZ)(s.Message))
+	}
+	return ret
+}
+
+// toSSZ returns the SSZ representation of d
+func (d *ProposalData) toSSZ() (*ProposalDataSSZ, error) {
+	rcj, err := roundChangeJustificationsToSSZ(d.RoundChangeJustification)
+	if err != nil {
+		return nil, err
+	}
+	pj, err := prepareJustificationsToSSZ(d.PrepareJustification)
+	if err != nil {
+		return nil, err
+	}
+	return &ProposalDataSSZ{
+		Data:                     d.Data,
+		RoundChangeJustification: rcj,
+		PrepareJustification:     pj,
+	}, nil
+}
+
+// EncodeWithVersion returns a msg encoded bytes according to version or error
+func (d *ProposalData) EncodeWithVersion(version EncodingVersion) ([]byte, error) {
+	return encodeWithVersion(version, d, func() (sszEncoder, error) {
+		return d.toSSZ()
+	})
+}
+
+// DecodeWithVersion returns error if decoding data encoded with version failed
+func (d *ProposalData) DecodeWithVersion(version EncodingVersion, data []byte) error {
+	if version == JSONEncodingVersion {
+		return d.Decode(data)
+	}
+	if version != SSZEncodingVersion {
+		return errors.New("unknown encoding version")
+	}
+	s := &ProposalDataSSZ{}
+	if err := s.UnmarshalSSZ(data); err != nil {
+		return errors.Wrap(err, "could not decode ssz proposal data")
+	}
+	d.Data = s.Data
+	d.RoundChangeJustification = roundChangeJustificationsFromSSZ(s.RoundChangeJustification)
+	d.PrepareJustification = prepareJustificationsFromSSZ(s.PrepareJustification)
+	return nil
+}
+
+// GetRootWithVersion returns the data's root according to version
+func (d *ProposalData) GetRootWithVersion(version EncodingVersion) ([]byte, error) {
+	return rootWithVersion(version, d, func() (sszEncoder, error) {
+		return d.toSSZ()
+	})
+}
+
+// EncodeWithVersion returns a msg encoded bytes according to version or error
+func (d *PrepareData) EncodeWithVersion(version EncodingVersion) ([]byte, error) {
+	return encodeWithVersion(version, d, func() (sszEncoder, error) {
+		return &PrepareDataSSZ{Data: d.Data}, nil
+	})
+}
+
+// DecodeWithVersion returns error if decoding data encoded with version failed
+func (d *PrepareData) DecodeWithVersion(version EncodingVersion, data []byte) error {
+	if version == JSONEncodingVersion {
+		return d.Decode(data)
+	}
+	if version != SSZEncodingVersion {
+		return errors.New("unknown encoding version")
+	}
+	s := &PrepareDataSSZ{}
+	if err := s.UnmarshalSSZ(data); err != nil {
+		return errors.Wrap(err, "could not decode ssz prepare data")
+	}
+	d.Data = s.Data
+	return nil
+}
+
+// GetRootWithVersion returns the data's root according to version
+func (d *PrepareData) GetRootWithVersion(version EncodingVersion) ([]byte, error) {
+	return rootWithVersion(version, d, func() (sszEncoder, error) {
+		return &PrepareDataSSZ{Data: d.Data}, nil
+	})
+}
+
+// EncodeWithVersion returns a msg encoded bytes according to version or error
+func (d *CommitData) EncodeWithVersion(version EncodingVersion) ([]byte, error) {
+	return encodeWithVersion(version, d, func() (sszEncoder, error) {
+		return &CommitDataSSZ{Data: d.Data}, nil
+	})
+}
+
+// DecodeWithVersion returns error if decoding data encoded with version failed
+func (d *CommitData) DecodeWithVersion(version EncodingVersion, data []byte) error {
+	if version == JSONEncodingVersion {
+		return d.Decode(data)
+	}
+	if version != SSZEncodingVersion {
+		return errors.New("unknown encoding version")
+	}
+	s := &CommitDataSSZ{}
+	if err := s.UnmarshalSSZ(data); err != nil {
+		return errors.Wrap(err, "could not decode ssz commit data")
+	}
+	d.Data = s.Data
+	return nil
+}
+
+// GetRootWithVersion returns the data's root according to version
+func (d *CommitData) GetRootWithVersion(version EncodingVersion) ([]byte, error) {
+	return rootWithVersion(version, d, func() (sszEncoder, error) {
+		return &CommitDataSSZ{Data: d.Data}, nil
+	})
+}
+
+// toSSZ returns the SSZ representation of d
+func (d *RoundChangeData) toSSZ() (*RoundChangeDataSSZ, error) {
+	rcj, err := prepareJustificationsToSSZ(d.RoundChangeJustification)
+	if err != nil {
+		return nil, err
+	}
+	return &RoundChangeDataSSZ{
+		PreparedValue:            d.PreparedValue,
+		PreparedRound:            uint64(d.PreparedRound),
+		RoundChangeJustification: rcj,
+	}, nil
+}
+
+// EncodeWithVersion returns a msg encoded bytes according to version or error
+func (d *RoundChangeData) EncodeWithVersion(version EncodingVersion) ([]byte, error) {
+	return encodeWithVersion(version, d, func() (sszEncoder, error) {
+		return d.toSSZ()
+	})
+}
+
+// DecodeWithVersion returns error if decoding data encoded with version failed
+func (d *RoundChangeData) DecodeWithVersion(version EncodingVersion, data []byte) error {
+	if version == JSONEncodingVersion {
+		return d.Decode(data)
+	}
+	if version != SSZEncodingVersion {
+		return errors.New("unknown encoding version")
+	}
+	s := &RoundChangeDataSSZ{}
+	if err := s.UnmarshalSSZ(data); err != nil {
+		return errors.Wrap(err, "could not decode ssz round change data")
+	}
+	d.PreparedValue = s.PreparedValue
+	d.PreparedRound = Round(s.PreparedRound)
+	d.RoundChangeJustification = prepareJustificationsFromSSZ(s.RoundChangeJustification)
+	return nil
+}
+
+// GetRootWithVersion returns the data's root according to version
+func (d *RoundChangeData) GetRootWithVersion(version EncodingVersion) ([]byte, error) {
+	return rootWithVersion(version, d, func() (sszEncoder, error) {
+		return d.toSSZ()
+	})
+}
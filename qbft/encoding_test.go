@@ -0,0 +1,169 @@
+package qbft
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSignedMessage_EncodeWithVersion(t *testing.T) {
+	prepareData, err := (&PrepareData{Data: []byte{1, 2, 3, 4}}).Encode()
+	require.NoError(t, err)
+	prepare := SignMsg(TestingSK, 1, &Message{
+		MsgType:    PrepareMsgType,
+		Height:     FirstHeight,
+		Round:      FirstRound,
+		Identifier: []byte{1, 2, 3, 4},
+		Data:       prepareData,
+	})
+
+	for _, version := range []EncodingVersion{JSONEncodingVersion, SSZEncodingVersion} {
+		byts, err := prepare.EncodeWithVersion(version)
+		require.NoError(t, err)
+
+		decoded := &SignedMessage{}
+		require.NoError(t, decoded.DecodeWithVersion(version, byts))
+		require.EqualValues(t, prepare.Signers, decoded.Signers)
+		require.EqualValues(t, prepare.Signature, decoded.Signature)
+
+		r1, err := prepare.GetRootWithVersion(version)
+		require.NoError(t, err)
+		r2, err := decoded.GetRootWithVersion(version)
+		require.NoError(t, err)
+		require.EqualValues(t, r1, r2)
+	}
+
+	t.Run("json root", func(t *testing.T) {
+		r1, err := prepare.GetRootWithVersion(JSONEncodingVersion)
+		require.NoError(t, err)
+		r2, err := prepare.GetRoot()
+		require.NoError(t, err)
+		require.EqualValues(t, r2, r1)
+	})
+
+	t.Run("ssz root", func(t *testing.T) {
+		r1, err := prepare.GetRootWithVersion(SSZEncodingVersion)
+		require.NoError(t, err)
+		s, err := prepare.Message.toSSZ()
+		require.NoError(t, err)
+		r2, err := s.HashTreeRoot()
+		require.NoError(t, err)
+		require.EqualValues(t, r2[:], r1)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := prepare.EncodeWithVersion(2)
+		require.EqualError(t, err, "unknown encoding version")
+		require.EqualError(t, (&SignedMessage{}).DecodeWithVersion(2, []byte{}), "unknown encoding version")
+	})
+
+	t.Run("negative height", func(t *testing.T) {
+		_, err := (&Message{Height: -1}).EncodeWithVersion(SSZEncodingVersion)
+		require.EqualError(t, err, "message height is invalid")
+	})
+
+	t.Run("invalid signature length", func(t *testing.T) {
+		_, err := (&SignedMessage{Signature: []byte{1, 2, 3}, Message: prepare.Message}).EncodeWithVersion(SSZEncodingVersion)
+		require.Error(t, err)
+	})
+}
+
+func TestProposalData_EncodeWithVersion(t *testing.T) {
+	d := &ProposalData{
+		Data:                     []byte{1, 2, 3, 4},
+		RoundChangeJustification: []*SignedMessage{testingSignedMsg},
+		PrepareJustification:     []*SignedMessage{testingSignedMsg, testingSignedMsg},
+	}
+
+	byts, err := d.EncodeWithVersion(SSZEncodingVersion)
+	require.NoError(t, err)
+
+	decoded := &ProposalData{}
+	require.NoError(t, decoded.DecodeWithVersion(SSZEncodingVersion, byts))
+	require.EqualValues(t, d.Data, decoded.Data)
+	require.Len(t, decoded.RoundChangeJustification, 1)
+	require.Len(t, decoded.PrepareJustification, 2)
+
+	r1, err := d.GetRootWithVersion(SSZEncodingVersion)
+	require.NoError(t, err)
+	r2, err := decoded.GetRootWithVersion(SSZEncodingVersion)
+	require.NoError(t, err)
+	require.EqualValues(t, r1, r2)
+}
+
+func TestRoundChangeData_EncodeWithVersion(t *testing.T) {
+	d := &RoundChangeData{
+		PreparedValue:            []byte{1, 2, 3, 4},
+		PreparedRound:            2,
+		RoundChangeJustification: []*SignedMessage{testingSignedMsg},
+	}
+
+	byts, err := d.EncodeWithVersion(SSZEncodingVersion)
+	require.NoError(t, err)
+
+	decoded := &RoundChangeData{}
+	require.NoError(t, decoded.DecodeWithVersion(SSZEncodingVersion, byts))
+	require.EqualValues(t, d.PreparedValue, decoded.PreparedValue)
+	require.EqualValues(t, d.PreparedRound, decoded.PreparedRound)
+	require.Len(t, decoded.RoundChangeJustification, 1)
+}
+
+func TestDetectEncodingVersion(t *testing.T) {
+	prepareData, err := (&PrepareData{Data: []byte{1, 2, 3, 4}}).EncodeWithVersion(SSZEncodingVersion)
+	require.NoError(t, err)
+	prepare := SignMsg(TestingSK, 1, &Message{
+		MsgType:    PrepareMsgType,
+		Height:     FirstHeight,
+		Round:      FirstRound,
+		Identifier: []byte{1, 2, 3, 4},
+		Data:       prepareData,
+	})
+
+	for _, version := range []EncodingVersion{JSONEncodingVersion, SSZEncodingVersion} {
+		byts, err := prepare.EncodeWithVersion(version)
+		require.NoError(t, err)
+		require.EqualValues(t, version, detectEncodingVersion(byts))
+
+		decoded := &SignedMessage{}
+		require.NoError(t, decoded.Decode(byts))
+		require.EqualValues(t, prepare.Signature, decoded.Signature)
+		require.EqualValues(t, prepare.Message.Data, decoded.Message.Data)
+	}
+
+	t.Run("ssz msg data", func(t *testing.T) {
+		require.EqualValues(t, SSZEncodingVersion, detectEncodingVersion(prepareData))
+		d, err := prepare.Message.GetPrepareData()
+		require.NoError(t, err)
+		require.EqualValues(t, []byte{1, 2, 3, 4}, d.Data)
+	})
+}
+
+func TestProposalData_SSZLimits(t *testing.T) {
+	prepareData, err := (&PrepareData{Data: make([]byte, 1048576)}).EncodeWithVersion(SSZEncodingVersion)
+	require.NoError(t, err)
+	rcData, err := (&RoundChangeData{
+		PreparedValue:            make([]byte, 1048576),
+		PreparedRound:            FirstRound,
+		RoundChangeJustification: []*SignedMessage{SignMsg(TestingSK, 1, &Message{Height: FirstHeight, Identifier: make([]byte, 56), Data: prepareData})},
+	}).EncodeWithVersion(SSZEncodingVersion)
+	require.NoError(t, err)
+
+	t.Run("max nested data", func(t *testing.T) {
+		d := &ProposalData{
+			Data:                     make([]byte, 1048576),
+			RoundChangeJustification: []*SignedMessage{SignMsg(TestingSK, 1, &Message{Height: FirstHeight, Data: rcData})},
+			PrepareJustification:     []*SignedMessage{SignMsg(TestingSK, 1, &Message{Height: FirstHeight, Data: prepareData})},
+		}
+		byts, err := d.EncodeWithVersion(SSZEncodingVersion)
+		require.NoError(t, err)
+		_, err = (&Message{Height: FirstHeight, Identifier: make([]byte, 56), Data: byts}).EncodeWithVersion(SSZEncodingVersion)
+		require.NoError(t, err)
+	})
+
+	t.Run("prepare justification exceeding prepare data", func(t *testing.T) {
+		d := &ProposalData{
+			PrepareJustification: []*SignedMessage{SignMsg(TestingSK, 1, &Message{Height: FirstHeight, Data: rcData})},
+		}
+		_, err := d.EncodeWithVersion(SSZEncodingVersion)
+		require.Error(t, err)
+	})
+}
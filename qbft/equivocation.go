@@ -0,0 +1,263 @@
+package qbft
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+// EquivocationF is called with every equivocation evidence an instance produces
+type EquivocationF func(evidence *EquivocationEvidence)
+
+// EquivocationEvidence proves an operator signed 2 conflicting msgs (same height, round and type with different values, see isConflictingMsg).
+// The evidence is signed by the reporting operator.
+type EquivocationEvidence struct {
+	FirstMsg  *SignedMessage
+	SecondMsg *SignedMessage
+	Reporter  types.OperatorID
+	Signature types.Signature
+	// EncodingVersion the conflicting msgs were signed with, omitted from the encoded evidence (and root) for JSONEncodingVersion
+	EncodingVersion EncodingVersion `json:",omitempty"`
+}
+
+// NewEquivocationEvidence returns a new evidence for the conflicting msgs signed by the state's operator
+func NewEquivocationEvidence(state *State, config IConfig, firstMsg *SignedMessage, secondMsg *SignedMessage) (*EquivocationEvidence, error) {
+	ret := &EquivocationEvidence{
+		FirstMsg:        firstMsg,
+		SecondMsg:       secondMsg,
+		Reporter:        state.Share.OperatorID,
+		EncodingVersion: config.GetEncodingVersion(),
+	}
+	sig, err := config.GetSigner().SignRoot(ret, types.EquivocationEvidenceSignatureType, state.Share.SharePubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed signing equivocation evidence")
+	}
+	ret.Signature = sig
+	return ret, nil
+}
+
+// GetSigner returns the operator which signed both conflicting msgs
+func (e *EquivocationEvidence) GetSigner() types.OperatorID {
+	return e.FirstMsg.Signers[0]
+}
+
+// GetRoot returns the root used for signing and verification (excluding the reporter's signature)
+func (e *EquivocationEvidence) GetRoot() ([]byte, error) {
+	rootStruct := struct {
+		FirstMsg        *SignedMessage
+		SecondMsg       *SignedMessage
+		Reporter        types.OperatorID
+		EncodingVersion EncodingVersion `json:",omitempty"`
+	}{
+		FirstMsg:        e.FirstMsg,
+		SecondMsg:       e.SecondMsg,
+		Reporter:        e.Reporter,
+		EncodingVersion: e.EncodingVersion,
+	}
+
+	marshaledRoot, err := json.Marshal(rootStruct)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode equivocation evidence")
+	}
+	ret := sha256.Sum256(marshaledRoot)
+	return ret[:], nil
+}
+
+// Encode returns the encoded struct in bytes or error
+func (e *EquivocationEvidence) Encode() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// Decode returns error if decoding failed
+func (e *EquivocationEvidence) Decode(data []byte) error {
+	return json.Unmarshal(data, &e)
+}
+
+// Validate returns error if the evidence doesn't prove an equivocation by one of the operators, or if not signed by the reporter
+func (e *EquivocationEvidence) Validate(domain types.DomainType, operators []*types.Operator) error {
+	if e.FirstMsg == nil || e.SecondMsg == nil {
+		return errors.New("evidence msgs missing")
+	}
+	if err := e.FirstMsg.Validate(); err != nil {
+		return errors.Wrap(err, "invalid first msg")
+	}
+	if err := e.SecondMsg.Validate(); err != nil {
+		return errors.Wrap(err, "invalid second msg")
+	}
+	if !isConflictingMsg(e.FirstMsg, e.SecondMsg) {
+		return errors.New("msgs not conflicting")
+	}
+
+	if err := e.FirstMsg.Signature.VerifyByOperators(e.FirstMsg.withVersion(e.EncodingVersion), domain, types.QBFTSignatureType, operators); err != nil {
+		return errors.Wrap(err, "first msg signature invalid")
+	}
+	if err := e.SecondMsg.Signature.VerifyByOperators(e.SecondMsg.withVersion(e.EncodingVersion), domain, types.QBFTSignatureType, operators); err != nil {
+		return errors.Wrap(err, "second msg signature invalid")
+	}
+
+	for _, operator := range operators {
+		if operator.OperatorID == e.Reporter {
+			if err := e.Signature.Verify(e, domain, types.EquivocationEvidenceSignatureType, operator.PubKey); err != nil {
+				return errors.Wrap(err, "evidence signature invalid")
+			}
+			return nil
+		}
+	}
+	return errors.New("unknown reporter")
+}
+
+// isConflictingMsg returns true if both msgs have a single (same) signer, same identifier, height, round and type but different values (see msgValueRoot).
+// Msgs differing only in their justifications (e.g. a leader re-proposing with more round change justifications) don't conflict
+func isConflictingMsg(msg1 *SignedMessage, msg2 *SignedMessage) bool {
+	if len(msg1.Signers) != 1 || !msg1.MatchedSigners(msg2.Signers) {
+		return false
+	}
+	if !bytes.Equal(msg1.Message.Identifier, msg2.Message.Identifier) ||
+		msg1.Message.Height != msg2.Message.Height ||
+		msg1.Message.Round != msg2.Message.Round ||
+		msg1.Message.MsgType != msg2.Message.MsgType {
+		return false
+	}
+
+	root1, err := msgValueRoot(msg1.Message)
+	if err != nil {
+		return false
+	}
+	root2, err := msgValueRoot(msg2.Message)
+	if err != nil {
+		return false
+	}
+	return !bytes.Equal(root1, root2)
+}
+
+// msgValueRoot returns the root of the value msg carries, the proposed, prepared or committed value (the prepared round and value for round changes), excluding justifications
+func msgValueRoot(msg *Message) ([]byte, error) {
+	var value []byte
+	switch msg.MsgType {
+	case ProposalMsgType:
+		data, err := msg.GetProposalData()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get proposal data")
+		}
+		value = data.Data
+	case PrepareMsgType:
+		data, err := msg.GetPrepareData()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get prepare data")
+		}
+		value = data.Data
+	case CommitMsgType:
+		data, err := msg.GetCommitData()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get commit data")
+		}
+		value = data.Data
+	case RoundChangeMsgType:
+		data, err := msg.GetRoundChangeData()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get round change data")
+		}
+		round := make([]byte, 8)
+		binary.BigEndian.PutUint64(round, uint64(data.PreparedRound))
+		value = append(round, data.PreparedValue...)
+	default:
+		return nil, errors.New("unknown msg type")
+	}
+
+	ret := sha256.Sum256(value)
+	return ret[:], nil
+}
+
+// equivocationKey identifies the msgs of a signer an equivocation can be reported for once
+type equivocationKey struct {
+	signer  types.OperatorID
+	height  Height
+	round   Round
+	msgType MessageType
+}
+
+// ConflictingMsg returns a stored msg conflicting with msg (see isConflictingMsg), nil if not found
+func (c *MsgContainer) ConflictingMsg(msg *SignedMessage) *SignedMessage {
+	for _, existingMsg := range c.MessagesForRound(msg.Message.Round) {
+		if isConflictingMsg(existingMsg, msg) {
+			return existingMsg
+		}
+	}
+	return nil
+}
+
+// containerForMsgType returns the instance's msg container for the msg type, nil if unknown
+func (i *Instance) containerForMsgType(msgType MessageType) *MsgContainer {
+	switch msgType {
+	case ProposalMsgType:
+		return i.State.ProposeContainer
+	case PrepareMsgType:
+		return i.State.PrepareContainer
+	case CommitMsgType:
+		return i.State.CommitContainer
+	case RoundChangeMsgType:
+		return i.State.RoundChangeContainer
+	default:
+		return nil
+	}
+}
+
+// detectEquivocation checks if msg conflicts with a msg previously stored (and verified) by the instance.
+// Only if it does, the msg's signature is verified and an evidence is created, passed to the EquivocationF (and the controller's misbehavior reporting) and saved if the storage is an EquivocationStorage.
+// An equivocation is reported once per signer, height, round and msg type, replayed conflicting msgs are ignored.
+// Returns the evidence if created, nil otherwise
+func (i *Instance) detectEquivocation(msg *SignedMessage) (*EquivocationEvidence, error) {
+	container := i.containerForMsgType(msg.Message.MsgType)
+	if container == nil {
+		return nil, nil
+	}
+	existingMsg := container.ConflictingMsg(msg)
+	if existingMsg == nil {
+		return nil, nil
+	}
+	key := equivocationKey{
+		signer:  msg.Signers[0],
+		height:  msg.Message.Height,
+		round:   msg.Message.Round,
+		msgType: msg.Message.MsgType,
+	}
+	if i.reportedEquivocations[key] {
+		return nil, nil
+	}
+
+	if err := verifyMsgSignature(i.config, msg, i.State.Share.Committee); err != nil {
+		return nil, errors.Wrap(err, "conflicting msg signature invalid")
+	}
+
+	evidence, err := NewEquivocationEvidence(i.State, i.config, existingMsg, msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create equivocation evidence")
+	}
+	if i.reportedEquivocations == nil {
+		i.reportedEquivocations = make(map[equivocationKey]bool)
+	}
+	i.reportedEquivocations[key] = true
+
+	if equivocationF := i.config.GetEquivocationF(); equivocationF != nil {
+		equivocationF(evidence)
+	}
+	if i.equivocationF != nil {
+		i.equivocationF(evidence)
+	}
+	if storage, ok := i.config.GetStorage().(EquivocationStorage); ok {
+		if err := storage.SaveEquivocationEvidence(evidence); err != nil {
+			return evidence, errors.Wrap(err, "could not save equivocation evidence")
+		}
+	}
+	return evidence, nil
+}
+
+// onMsgEquivocation detects equivocation for msg, processing the msg shouldn't fail on detection errors
+func (i *Instance) onMsgEquivocation(msg *SignedMessage) {
+	if _, err := i.detectEquivocation(msg); err != nil {
+		i.config.GetLogger().Error(err, msgLogFields(msg))
+	}
+}
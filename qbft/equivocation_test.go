@@ -0,0 +1,129 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInstance_Equivocation(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	prepare := func(id types.OperatorID, value []byte) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+			MsgType:    qbft.PrepareMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.PrepareDataBytes(value),
+		})
+	}
+	proposal := testingutils.SignQBFTMsg(ks.Shares[1], 1, &qbft.Message{
+		MsgType:    qbft.ProposalMsgType,
+		Height:     qbft.FirstHeight,
+		Round:      qbft.FirstRound,
+		Identifier: []byte{1, 2, 3, 4},
+		Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil),
+	})
+
+	setup := func() (*qbft.Instance, *[]*qbft.EquivocationEvidence) {
+		evidence := make([]*qbft.EquivocationEvidence, 0)
+		inst := testingutils.BaseInstance()
+		inst.GetConfig().(*qbft.Config).EquivocationF = func(e *qbft.EquivocationEvidence) {
+			evidence = append(evidence, e)
+		}
+		_, _, _, err := inst.ProcessMsg(proposal)
+		require.NoError(t, err)
+		_, _, _, err = inst.ProcessMsg(prepare(2, []byte{1, 2, 3, 4}))
+		require.NoError(t, err)
+		return inst, &evidence
+	}
+
+	t.Run("conflicting prepare", func(t *testing.T) {
+		inst, evidence := setup()
+
+		_, _, _, err := inst.ProcessMsg(prepare(2, []byte{5, 6, 7, 8}))
+		require.EqualError(t, err, "invalid prepare msg: prepare data != proposed data")
+		require.Len(t, *evidence, 1)
+
+		e := (*evidence)[0]
+		require.EqualValues(t, types.OperatorID(2), e.GetSigner())
+		require.EqualValues(t, types.OperatorID(1), e.Reporter)
+		require.NoError(t, e.Validate(types.PrimusTestnet, ks.Committee()))
+
+		byts, err := e.Encode()
+		require.NoError(t, err)
+		decoded := &qbft.EquivocationEvidence{}
+		require.NoError(t, decoded.Decode(byts))
+		require.NoError(t, decoded.Validate(types.PrimusTestnet, ks.Committee()))
+	})
+
+	t.Run("same prepare", func(t *testing.T) {
+		inst, evidence := setup()
+
+		_, _, _, err := inst.ProcessMsg(prepare(2, []byte{1, 2, 3, 4}))
+		require.NoError(t, err)
+		require.Len(t, *evidence, 0)
+	})
+
+	t.Run("replayed conflicting prepare", func(t *testing.T) {
+		inst, evidence := setup()
+
+		conflicting := prepare(2, []byte{5, 6, 7, 8})
+		_, _, _, _ = inst.ProcessMsg(conflicting)
+		_, _, _, _ = inst.ProcessMsg(conflicting)
+		_, _, _, _ = inst.ProcessMsg(prepare(2, []byte{9, 10, 11, 12}))
+		require.Len(t, *evidence, 1)
+	})
+
+	t.Run("same value different justifications", func(t *testing.T) {
+		inst, evidence := setup()
+
+		rc := testingutils.SignQBFTMsg(ks.Shares[2], 2, &qbft.Message{
+			MsgType:    qbft.RoundChangeMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+		})
+		reproposal := testingutils.SignQBFTMsg(ks.Shares[1], 1, &qbft.Message{
+			MsgType:    qbft.ProposalMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, []*qbft.SignedMessage{rc}, nil),
+		})
+		_, _, _, _ = inst.ProcessMsg(reproposal)
+		require.Len(t, *evidence, 0)
+
+		e, err := qbft.NewEquivocationEvidence(inst.State, inst.GetConfig(), proposal, reproposal)
+		require.NoError(t, err)
+		require.EqualError(t, e.Validate(types.PrimusTestnet, ks.Committee()), "msgs not conflicting")
+	})
+
+	t.Run("conflicting prepare invalid signature", func(t *testing.T) {
+		inst, evidence := setup()
+
+		msg := prepare(2, []byte{5, 6, 7, 8})
+		msg.Signature = prepare(3, []byte{5, 6, 7, 8}).Signature
+		_, _, _, err := inst.ProcessMsg(msg)
+		require.Error(t, err)
+		require.Len(t, *evidence, 0)
+	})
+
+	t.Run("tampered evidence", func(t *testing.T) {
+		inst, evidence := setup()
+
+		_, _, _, _ = inst.ProcessMsg(prepare(2, []byte{5, 6, 7, 8}))
+		require.Len(t, *evidence, 1)
+
+		e := (*evidence)[0]
+		e.Reporter = 3
+		require.EqualError(t, e.Validate(types.PrimusTestnet, ks.Committee()), "evidence signature invalid: failed to verify signature")
+
+		e.Reporter = 1
+		e.SecondMsg = e.FirstMsg
+		require.EqualError(t, e.Validate(types.PrimusTestnet, ks.Committee()), "msgs not conflicting")
+	})
+}
@@ -32,9 +32,10 @@ func validateFutureMsg(
 	}
 
 	// verify signature
-	if err := msg.Signature.VerifyByOperators(msg, config.GetSignatureDomainType(), types.QBFTSignatureType, operators); err != nil {
+	if err := verifyMsgSignature(config, msg, operators); err != nil {
 		return errors.Wrap(err, "commit msg signature invalid")
 	}
+	msg.markSignatureVerified(config, operators)
 
 	return nil
 }
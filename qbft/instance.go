@@ -2,12 +2,14 @@ package qbft
 
 import (
 	"encoding/json"
-	"fmt"
 	"github.com/bloxapp/ssv-spec/types"
 	"github.com/pkg/errors"
 	"sync"
 )
 
+// ErrInstanceStopped is returned for msgs processed by a stopped instance
+var ErrInstanceStopped = errors.New("instance stopped")
+
 type ProposedValueCheckF func(data []byte) error
 type ProposerF func(state *State, round Round) types.OperatorID
 
@@ -16,6 +18,18 @@ type ProposerF func(state *State, round Round) types.OperatorID
 type Instance struct {
 	State  *State
 	config IConfig
+	// timer is created lazily by GetTimer if the config has an InstanceTimerF
+	timer Timer
+	// decidedMsg is the decided msg saved and broadcasted for the instance, replaced by aggregated late commits with more signers
+	decidedMsg *SignedMessage
+	// broadcastF is set by a controller checkpointing its state to hold broadcasted msgs until checkpointed, nil broadcasts to the network
+	broadcastF func(msg *types.SSVMessage) error
+	// equivocationF is set by a controller to report equivocations as misbehavior (see Controller.reportEquivocation), on top of the config's EquivocationF
+	equivocationF func(evidence *EquivocationEvidence)
+	// providedValue is the value proposed for the latest round the instance proposed a value from the config's ValueProvider for
+	providedValue *providedValue
+	// reportedEquivocations are the equivocations already reported by detectEquivocation
+	reportedEquivocations map[equivocationKey]bool
 
 	processMsgF *types.ThreadSafeF
 	startOnce   sync.Once
@@ -48,33 +62,37 @@ func NewInstance(
 // Start is an interface implementation
 func (i *Instance) Start(value []byte, height Height) {
 	i.startOnce.Do(func() {
+		if i.IsStopped() {
+			return
+		}
 		i.StartValue = value
 		i.State.Round = FirstRound
 		i.State.Height = height
 
-		i.config.GetTimer().TimeoutForRound(FirstRound)
+		i.timeoutForRound(FirstRound)
+		i.emit(InstanceStartedEvent, nil, value, nil)
 
 		// propose if this node is the proposer
 		if proposer(i.State, i.GetConfig(), FirstRound) == i.State.Share.OperatorID {
 			proposal, err := CreateProposal(i.State, i.config, i.StartValue, nil, nil)
 			// nolint
 			if err != nil {
-				fmt.Printf("%s\n", err.Error())
+				i.logError(errors.Wrap(err, "could not create proposal"))
 			}
 			// nolint
 			if err := i.Broadcast(proposal); err != nil {
-				fmt.Printf("%s\n", err.Error())
+				i.logError(errors.Wrap(err, "could not broadcast proposal"))
 			}
 		}
 
 		if err := i.config.GetNetwork().SyncHighestRoundChange(types.MessageIDFromBytes(i.State.ID), i.State.Height); err != nil {
-			fmt.Printf("%s\n", err.Error())
+			i.logError(errors.Wrap(err, "could not sync highest round change"))
 		}
 	})
 }
 
 func (i *Instance) Broadcast(msg *SignedMessage) error {
-	byts, err := msg.Encode()
+	byts, err := msg.EncodeWithVersion(i.config.GetEncodingVersion())
 	if err != nil {
 		return errors.Wrap(err, "could not encode message")
 	}
@@ -87,40 +105,106 @@ func (i *Instance) Broadcast(msg *SignedMessage) error {
 		MsgID:   msgID,
 		Data:    byts,
 	}
+	if i.broadcastF != nil {
+		return i.broadcastF(msgToBroadcast)
+	}
 	return i.config.GetNetwork().Broadcast(msgToBroadcast)
 }
 
 // ProcessMsg processes a new QBFT msg, returns non nil error on msg processing error
 func (i *Instance) ProcessMsg(msg *SignedMessage) (decided bool, decidedValue []byte, aggregatedCommit *SignedMessage, err error) {
 	if err := msg.Validate(); err != nil {
-		return false, nil, nil, errors.Wrap(err, "invalid signed message")
+		err = errors.Wrap(err, "invalid signed message")
+		i.emit(MessageRejectedEvent, msg, nil, err)
+		return false, nil, nil, err
+	}
+
+	valCheck := i.config.GetValueCheckF()
+	if i.config.GetAsyncValueCheck() {
+		valCheck = i.preCheckValue(msg, valCheck)
 	}
 
 	res := i.processMsgF.Run(func() interface{} {
+		if i.State.Stopped {
+			return ErrInstanceStopped
+		}
+		defer i.checkInvariants(msg, i.snapshotInvariants())
+		i.onMsgEquivocation(msg)
+
 		switch msg.Message.MsgType {
 		case ProposalMsgType:
-			return i.uponProposal(msg, i.State.ProposeContainer)
+			return i.uponProposal(msg, i.State.ProposeContainer, valCheck)
 		case PrepareMsgType:
 			return i.uponPrepare(msg, i.State.PrepareContainer, i.State.CommitContainer)
 		case CommitMsgType:
 			decided, decidedValue, aggregatedCommit, err = i.UponCommit(msg, i.State.CommitContainer)
 			if decided {
+				prevDecided := i.State.Decided
 				i.State.Decided = decided
 				i.State.DecidedValue = decidedValue
+				if !prevDecided {
+					i.emit(DecidedEvent, msg, decidedValue, nil)
+				}
 			}
 			return err
 		case RoundChangeMsgType:
-			return i.uponRoundChange(i.StartValue, msg, i.State.RoundChangeContainer, i.config.GetValueCheckF())
+			return i.uponRoundChange(i.StartValue, msg, i.State.RoundChangeContainer, valCheck)
 		default:
 			return errors.New("signed message type not supported")
 		}
 	})
 	if res != nil {
+		i.emit(MessageRejectedEvent, msg, nil, res.(error))
 		return false, nil, nil, res.(error)
 	}
 	return i.State.Decided, i.State.DecidedValue, aggregatedCommit, nil
 }
 
+// ProcessSyncedRoundChanges processes a batch of synced round change msgs (see Network.SyncHighestRoundChange) at once, letting an instance that (re)started mid-instance join the current round without waiting for timeouts.
+// Unlike processing each msg with ProcessMsg, the instance jumps directly to the highest round with f+1 round change msgs (of that round or higher) and proposes if leader for it with a justified round change quorum.
+// A decided instance ignores the msgs
+func (i *Instance) ProcessSyncedRoundChanges(msgs []*SignedMessage) error {
+	res := i.processMsgF.Run(func() interface{} {
+		if i.State.Stopped {
+			return ErrInstanceStopped
+		}
+		if i.State.Decided {
+			return nil
+		}
+		defer i.checkInvariants(nil, i.snapshotInvariants())
+		return i.uponSyncedRoundChanges(i.StartValue, msgs, i.State.RoundChangeContainer, i.config.GetValueCheckF())
+	})
+	if res != nil {
+		return res.(error)
+	}
+	return nil
+}
+
+// Stop terminates the instance (e.g. once its duty expired), stopping its round timer if it implements StoppableTimer.
+// A stopped instance doesn't start, rejects all msgs with ErrInstanceStopped and ignores timeouts, so it never broadcasts again.
+// With a timer shared between instances (no InstanceTimerF) the shared timer is stopped, so only the latest instance should be stopped
+func (i *Instance) Stop() {
+	i.processMsgF.Run(func() interface{} {
+		if i.State.Stopped {
+			return nil
+		}
+		i.State.Stopped = true
+		if timer, ok := i.GetTimer().(StoppableTimer); ok {
+			timer.Stop()
+		}
+		i.emit(InstanceStoppedEvent, nil, nil, nil)
+		return nil
+	})
+}
+
+// IsStopped returns true if the instance was stopped
+func (i *Instance) IsStopped() bool {
+	if state := i.State; state != nil {
+		return state.Stopped
+	}
+	return false
+}
+
 // IsDecided interface implementation
 func (i *Instance) IsDecided() (bool, []byte) {
 	if state := i.State; state != nil {
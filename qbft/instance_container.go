@@ -0,0 +1,166 @@
+package qbft
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// HistoricalInstanceCapacity represents the default upper bound of InstanceContainer a processmsg can process messages for as messages are not
+// guaranteed to arrive in a timely fashion, we physically limit how far back the processmsg will process messages for
+const HistoricalInstanceCapacity int = 5
+
+// InstanceEvictionF is called with every instance evicted from a controller's InstanceContainer
+type InstanceEvictionF func(instance *Instance) error
+
+// InstanceContainer is a ring buffer holding the last Capacity instances, adding an instance to a full container evicts the oldest one
+type InstanceContainer struct {
+	instances []*Instance
+	newest    int // index of the newest instance in instances
+	size      int
+}
+
+// NewInstanceContainer returns a container for capacity instances (HistoricalInstanceCapacity if capacity < 1), holding the given instances ordered newest first
+func NewInstanceContainer(capacity int, instances ...*Instance) *InstanceContainer {
+	if capacity < 1 {
+		capacity = HistoricalInstanceCapacity
+	}
+	ret := &InstanceContainer{
+		instances: make([]*Instance, capacity),
+		newest:    capacity - 1,
+	}
+	for idx := len(instances) - 1; idx >= 0; idx-- {
+		if instances[idx] != nil {
+			ret.addNewInstance(instances[idx])
+		}
+	}
+	return ret
+}
+
+func (i *InstanceContainer) FindInstance(height Height) *Instance {
+	for _, inst := range i.Instances() {
+		if inst.GetHeight() == height {
+			return inst
+		}
+	}
+	return nil
+}
+
+// Instances returns all stored instances ordered newest first
+func (i *InstanceContainer) Instances() []*Instance {
+	ret := make([]*Instance, 0, i.Len())
+	for idx := 0; idx < i.Len(); idx++ {
+		ret = append(ret, i.instances[(i.newest-idx+len(i.instances))%len(i.instances)])
+	}
+	return ret
+}
+
+// Len returns the number of stored instances
+func (i *InstanceContainer) Len() int {
+	if i == nil {
+		return 0
+	}
+	return i.size
+}
+
+// Capacity returns the max number of stored instances
+func (i *InstanceContainer) Capacity() int {
+	if i == nil {
+		return 0
+	}
+	return len(i.instances)
+}
+
+// Oldest returns the oldest stored instance, nil if empty
+func (i *InstanceContainer) Oldest() *Instance {
+	if i.Len() == 0 {
+		return nil
+	}
+	return i.instances[(i.newest-i.size+1+len(i.instances))%len(i.instances)]
+}
+
+// IsFull returns true if adding a new instance will evict the oldest one
+func (i *InstanceContainer) IsFull() bool {
+	return i.Len() == i.Capacity()
+}
+
+// addNewInstance will add the new instance as the newest, evicting (and returning) the oldest stored instance if full
+func (i *InstanceContainer) addNewInstance(instance *Instance) *Instance {
+	if i.instances == nil {
+		*i = *NewInstanceContainer(HistoricalInstanceCapacity)
+	}
+
+	i.newest = (i.newest + 1) % len(i.instances)
+	evicted := i.instances[i.newest]
+	i.instances[i.newest] = instance
+	if i.size < len(i.instances) {
+		i.size++
+	}
+	return evicted
+}
+
+// resize changes the container's capacity keeping the newest instances, returns evicted instances (oldest first)
+func (i *InstanceContainer) resize(capacity int) []*Instance {
+	stored := i.Instances()
+	evicted := make([]*Instance, 0)
+	for len(stored) > capacity {
+		evicted = append(evicted, stored[len(stored)-1])
+		stored = stored[:len(stored)-1]
+	}
+	*i = *NewInstanceContainer(capacity, stored...)
+	return evicted
+}
+
+// MarshalJSON encodes the container as an array of Capacity instances ordered newest first, empty slots encoded as null
+func (i *InstanceContainer) MarshalJSON() ([]byte, error) {
+	ret := make([]*Instance, i.Capacity())
+	copy(ret, i.Instances())
+	return json.Marshal(ret)
+}
+
+// UnmarshalJSON decodes an array of instances ordered newest first, its length being the container's capacity
+func (i *InstanceContainer) UnmarshalJSON(data []byte) error {
+	instances := make([]*Instance, 0)
+	if err := json.Unmarshal(data, &instances); err != nil {
+		return errors.Wrap(err, "could not decode instance container")
+	}
+	*i = *NewInstanceContainer(len(instances), instances...)
+	return nil
+}
+
+// SaveDecidedOnEviction returns an InstanceEvictionF saving the decided msg aggregated from the evicted instance's commit msgs to storage.
+// As commits keep arriving after an instance decided, the evicted instance's decided msg can have more signers than the one saved upon deciding.
+func SaveDecidedOnEviction(storage HistoricalStorage) InstanceEvictionF {
+	return func(instance *Instance) error {
+		decided, err := instance.aggregatedDecided()
+		if err != nil {
+			return errors.Wrap(err, "could not aggregate evicted instance's decided msg")
+		}
+		if decided == nil {
+			return nil
+		}
+		if err := storage.SaveDecided(decided); err != nil {
+			return errors.Wrap(err, "could not save evicted instance's decided msg")
+		}
+		return nil
+	}
+}
+
+// aggregatedDecided returns the aggregation of all commit msgs for the decided round and value, nil if not decided or no commit quorum found (e.g. decided by a decided msg)
+func (i *Instance) aggregatedDecided() (*SignedMessage, error) {
+	if decided, _ := i.IsDecided(); !decided || i.State.CommitContainer == nil {
+		return nil, nil
+	}
+
+	commitData, err := (&CommitData{Data: i.State.DecidedValue}).EncodeWithVersion(i.config.GetEncodingVersion())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode commit data")
+	}
+	quorum, msgs, err := commitQuorumForRoundValue(i.State, i.State.CommitContainer, commitData, i.State.Round)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not calculate commit quorum")
+	}
+	if !quorum {
+		return nil, nil
+	}
+	return aggregateCommitMsgs(msgs)
+}
@@ -0,0 +1,98 @@
+package qbft
+
+import (
+	"bytes"
+)
+
+// Invariant is a safety invariant every instance state transition (msg processing, round timeout) must hold
+type Invariant string
+
+const (
+	RoundNeverDecreasesInvariant               Invariant = "round never decreases"
+	LastPreparedRoundNeverDecreasesInvariant   Invariant = "last prepared round never decreases"
+	LastPreparedValueChangesWithRoundInvariant Invariant = "last prepared value only changes with a higher last prepared round"
+	DecidedNeverRevertsInvariant               Invariant = "decided instance never reverts to not decided"
+	DecidedValueNeverChangesInvariant          Invariant = "decided value never changes"
+	HeightNeverChangesInvariant                Invariant = "height never changes"
+)
+
+// InvariantViolationF is called with every invariant violation an instance detects
+type InvariantViolationF func(violation *InvariantViolation)
+
+// StateSnapshot is a copy of the State fields covered by the invariants
+type StateSnapshot struct {
+	Height            Height
+	Round             Round
+	LastPreparedRound Round
+	LastPreparedValue []byte
+	Decided           bool
+	DecidedValue      []byte
+}
+
+// InvariantViolation is a state transition violating an invariant
+type InvariantViolation struct {
+	Identifier []byte
+	Invariant  Invariant
+	// Msg is the processed msg, nil for round timeouts
+	Msg    *SignedMessage
+	Before *StateSnapshot
+	After  *StateSnapshot
+}
+
+// snapshotInvariants returns a snapshot of the instance's state if the config has an InvariantViolationF, nil otherwise.
+// Values are not copied as state transitions replace (never modify) them
+func (i *Instance) snapshotInvariants() *StateSnapshot {
+	if i.config.GetInvariantViolationF() == nil {
+		return nil
+	}
+	return &StateSnapshot{
+		Height:            i.State.Height,
+		Round:             i.State.Round,
+		LastPreparedRound: i.State.LastPreparedRound,
+		LastPreparedValue: i.State.LastPreparedValue,
+		Decided:           i.State.Decided,
+		DecidedValue:      i.State.DecidedValue,
+	}
+}
+
+// checkInvariants passes every invariant violated by the transition from before to the instance's current state to the config's InvariantViolationF.
+// Called deferred by state transitions with the snapshot taken before the transition, a nil snapshot (no InvariantViolationF) is ignored
+func (i *Instance) checkInvariants(msg *SignedMessage, before *StateSnapshot) {
+	if before == nil {
+		return
+	}
+	after := i.snapshotInvariants()
+
+	for _, invariant := range violatedInvariants(before, after) {
+		i.config.GetInvariantViolationF()(&InvariantViolation{
+			Identifier: i.State.ID,
+			Invariant:  invariant,
+			Msg:        msg,
+			Before:     before,
+			After:      after,
+		})
+	}
+}
+
+func violatedInvariants(before *StateSnapshot, after *StateSnapshot) []Invariant {
+	ret := make([]Invariant, 0)
+	if after.Height != before.Height {
+		ret = append(ret, HeightNeverChangesInvariant)
+	}
+	if after.Round < before.Round {
+		ret = append(ret, RoundNeverDecreasesInvariant)
+	}
+	if after.LastPreparedRound < before.LastPreparedRound {
+		ret = append(ret, LastPreparedRoundNeverDecreasesInvariant)
+	}
+	if !bytes.Equal(after.LastPreparedValue, before.LastPreparedValue) && after.LastPreparedRound <= before.LastPreparedRound {
+		ret = append(ret, LastPreparedValueChangesWithRoundInvariant)
+	}
+	if before.Decided && !after.Decided {
+		ret = append(ret, DecidedNeverRevertsInvariant)
+	}
+	if before.Decided && !bytes.Equal(after.DecidedValue, before.DecidedValue) {
+		ret = append(ret, DecidedValueNeverChangesInvariant)
+	}
+	return ret
+}
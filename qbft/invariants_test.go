@@ -0,0 +1,106 @@
+package qbft
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInstance_checkInvariants(t *testing.T) {
+	violations := make([]*InvariantViolation, 0)
+	config := &Config{InvariantViolationF: func(violation *InvariantViolation) {
+		violations = append(violations, violation)
+	}}
+	newInstance := func() *Instance {
+		violations = make([]*InvariantViolation, 0)
+		i := NewInstance(config, testingShare, []byte{1, 2, 3, 4}, FirstHeight)
+		i.State.Round = 3
+		i.State.LastPreparedRound = 2
+		i.State.LastPreparedValue = []byte{1, 2, 3, 4}
+		return i
+	}
+
+	t.Run("not checked", func(t *testing.T) {
+		i := NewInstance(&Config{}, testingShare, []byte{1, 2, 3, 4}, FirstHeight)
+		require.Nil(t, i.snapshotInvariants())
+		i.State.Round = 0
+		i.checkInvariants(nil, nil)
+	})
+
+	t.Run("valid transition", func(t *testing.T) {
+		i := newInstance()
+		before := i.snapshotInvariants()
+		i.State.Round = 4
+		i.State.LastPreparedRound = 4
+		i.State.LastPreparedValue = []byte{1, 2, 3, 5}
+		i.State.Decided = true
+		i.State.DecidedValue = []byte{1, 2, 3, 5}
+		i.checkInvariants(nil, before)
+		require.Empty(t, violations)
+
+		before = i.snapshotInvariants()
+		i.checkInvariants(testingSignedMsg, before)
+		require.Empty(t, violations)
+	})
+
+	t.Run("round decreased", func(t *testing.T) {
+		i := newInstance()
+		before := i.snapshotInvariants()
+		i.State.Round = 2
+		i.checkInvariants(testingSignedMsg, before)
+		require.Len(t, violations, 1)
+		require.EqualValues(t, RoundNeverDecreasesInvariant, violations[0].Invariant)
+		require.EqualValues(t, testingSignedMsg, violations[0].Msg)
+		require.EqualValues(t, i.State.ID, violations[0].Identifier)
+		require.EqualValues(t, 3, violations[0].Before.Round)
+		require.EqualValues(t, 2, violations[0].After.Round)
+	})
+
+	t.Run("last prepared round decreased", func(t *testing.T) {
+		i := newInstance()
+		before := i.snapshotInvariants()
+		i.State.LastPreparedRound = 1
+		i.checkInvariants(nil, before)
+		require.Len(t, violations, 1)
+		require.EqualValues(t, LastPreparedRoundNeverDecreasesInvariant, violations[0].Invariant)
+	})
+
+	t.Run("last prepared value changed", func(t *testing.T) {
+		i := newInstance()
+		before := i.snapshotInvariants()
+		i.State.LastPreparedValue = []byte{1, 2, 3, 5}
+		i.checkInvariants(nil, before)
+		require.Len(t, violations, 1)
+		require.EqualValues(t, LastPreparedValueChangesWithRoundInvariant, violations[0].Invariant)
+	})
+
+	t.Run("decided reverted", func(t *testing.T) {
+		i := newInstance()
+		i.State.Decided = true
+		i.State.DecidedValue = []byte{1, 2, 3, 4}
+		before := i.snapshotInvariants()
+		i.State.Decided = false
+		i.checkInvariants(nil, before)
+		require.Len(t, violations, 1)
+		require.EqualValues(t, DecidedNeverRevertsInvariant, violations[0].Invariant)
+	})
+
+	t.Run("decided value changed", func(t *testing.T) {
+		i := newInstance()
+		i.State.Decided = true
+		i.State.DecidedValue = []byte{1, 2, 3, 4}
+		before := i.snapshotInvariants()
+		i.State.DecidedValue = []byte{1, 2, 3, 5}
+		i.checkInvariants(nil, before)
+		require.Len(t, violations, 1)
+		require.EqualValues(t, DecidedValueNeverChangesInvariant, violations[0].Invariant)
+	})
+
+	t.Run("height changed", func(t *testing.T) {
+		i := newInstance()
+		before := i.snapshotInvariants()
+		i.State.Height = 2
+		i.checkInvariants(nil, before)
+		require.Len(t, violations, 1)
+		require.EqualValues(t, HeightNeverChangesInvariant, violations[0].Invariant)
+	})
+}
@@ -0,0 +1,92 @@
+package qbft
+
+import (
+	"bytes"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+// MaxJustificationMsgs is the max number of msgs a single justification (round change or prepare) can carry, equal to the max committee size.
+// Limits malicious peers from bloating round change and proposal msgs
+const MaxJustificationMsgs = 13
+
+// compactPrepareJustification aggregates prepares with the same root into a single multi signer msg.
+// Prepares with signers already aggregated for their root are dropped, returned msgs are ordered by first appearance of their root
+func compactPrepareJustification(prepares []*SignedMessage) ([]*SignedMessage, error) {
+	if len(prepares) == 0 {
+		return prepares, nil
+	}
+	ret := make([]*SignedMessage, 0)
+	roots := make([][]byte, 0)
+	for _, msg := range prepares {
+		r, err := msg.GetRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get prepare root")
+		}
+
+		aggregated := false
+		for idx, root := range roots {
+			if !bytes.Equal(root, r) {
+				continue
+			}
+			aggregated = true
+			if ret[idx].CommonSigners(msg.Signers) {
+				break // duplicate
+			}
+			if err := ret[idx].Aggregate(msg); err != nil {
+				return nil, errors.Wrap(err, "could not aggregate prepare")
+			}
+			break
+		}
+		if !aggregated {
+			ret = append(ret, msg.DeepCopy())
+			roots = append(roots, r)
+		}
+	}
+	return ret, nil
+}
+
+// dedupeJustification returns msgs without msgs with the same root and signers as a previous msg
+func dedupeJustification(msgs []*SignedMessage) ([]*SignedMessage, error) {
+	if len(msgs) == 0 {
+		return msgs, nil
+	}
+	ret := make([]*SignedMessage, 0)
+	roots := make([][]byte, 0)
+	for _, msg := range msgs {
+		r, err := msg.GetRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get justification root")
+		}
+
+		duplicate := false
+		for idx, root := range roots {
+			if bytes.Equal(root, r) && ret[idx].MatchedSigners(msg.Signers) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			ret = append(ret, msg)
+			roots = append(roots, r)
+		}
+	}
+	return ret, nil
+}
+
+// validPrepareJustification returns nil if all prepares are valid for height, round and value.
+// Justification prepares can be aggregated (multi signer), quorum is counted by unique signers (see HasQuorum)
+func validPrepareJustification(
+	config IConfig,
+	prepares []*SignedMessage,
+	height Height,
+	round Round,
+	value []byte,
+	operators []*types.Operator) error {
+	for _, pm := range prepares {
+		if err := validSignedPrepareJustificationForHeightRoundAndValue(config, pm, height, round, value, operators); err != nil {
+			return err
+		}
+	}
+	return nil
+}
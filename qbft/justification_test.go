@@ -0,0 +1,76 @@
+package qbft
+
+import (
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func testingPrepare(t *testing.T, id types.OperatorID, value []byte) *SignedMessage {
+	prepareData, err := (&PrepareData{Data: value}).Encode()
+	require.NoError(t, err)
+	return SignMsg(TestingSK, id, &Message{
+		MsgType:    PrepareMsgType,
+		Height:     FirstHeight,
+		Round:      FirstRound,
+		Identifier: []byte{1, 2, 3, 4},
+		Data:       prepareData,
+	})
+}
+
+func TestCompactPrepareJustification(t *testing.T) {
+	t.Run("aggregate same root", func(t *testing.T) {
+		prepares := []*SignedMessage{
+			testingPrepare(t, 1, []byte{1, 2, 3, 4}),
+			testingPrepare(t, 2, []byte{1, 2, 3, 4}),
+			testingPrepare(t, 3, []byte{1, 2, 3, 4}),
+		}
+		compact, err := compactPrepareJustification(prepares)
+		require.NoError(t, err)
+		require.Len(t, compact, 1)
+		require.EqualValues(t, []types.OperatorID{1, 2, 3}, compact[0].Signers)
+
+		// input msgs are not mutated
+		require.EqualValues(t, []types.OperatorID{1}, prepares[0].Signers)
+	})
+
+	t.Run("dedupe signers", func(t *testing.T) {
+		compact, err := compactPrepareJustification([]*SignedMessage{
+			testingPrepare(t, 1, []byte{1, 2, 3, 4}),
+			testingPrepare(t, 1, []byte{1, 2, 3, 4}),
+			testingPrepare(t, 2, []byte{1, 2, 3, 4}),
+		})
+		require.NoError(t, err)
+		require.Len(t, compact, 1)
+		require.EqualValues(t, []types.OperatorID{1, 2}, compact[0].Signers)
+	})
+
+	t.Run("different roots", func(t *testing.T) {
+		compact, err := compactPrepareJustification([]*SignedMessage{
+			testingPrepare(t, 1, []byte{1, 2, 3, 4}),
+			testingPrepare(t, 2, []byte{1, 2, 3, 5}),
+			testingPrepare(t, 3, []byte{1, 2, 3, 4}),
+		})
+		require.NoError(t, err)
+		require.Len(t, compact, 2)
+		require.EqualValues(t, []types.OperatorID{1, 3}, compact[0].Signers)
+		require.EqualValues(t, []types.OperatorID{2}, compact[1].Signers)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		compact, err := compactPrepareJustification(nil)
+		require.NoError(t, err)
+		require.Nil(t, compact)
+	})
+}
+
+func TestDedupeJustification(t *testing.T) {
+	deduped, err := dedupeJustification([]*SignedMessage{
+		testingPrepare(t, 1, []byte{1, 2, 3, 4}),
+		testingPrepare(t, 1, []byte{1, 2, 3, 4}),
+		testingPrepare(t, 2, []byte{1, 2, 3, 4}),
+		testingPrepare(t, 1, []byte{1, 2, 3, 5}),
+	})
+	require.NoError(t, err)
+	require.Len(t, deduped, 3)
+}
@@ -0,0 +1,126 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// decideWithQuorum starts a new instance and decides it with proposal, prepares and commits from operators 1-3
+func decideWithQuorum(t *testing.T, c *qbft.Controller) {
+	ks := testingutils.Testing4SharesSet()
+	require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+
+	msg := func(id types.OperatorID, msgType qbft.MessageType, data []byte) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+			MsgType:    msgType,
+			Height:     c.Height,
+			Round:      qbft.FirstRound,
+			Identifier: c.Identifier,
+			Data:       data,
+		})
+	}
+
+	_, err := c.ProcessMsg(msg(1, qbft.ProposalMsgType, testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil)))
+	require.NoError(t, err)
+	for _, id := range []types.OperatorID{1, 2, 3} {
+		_, err := c.ProcessMsg(msg(id, qbft.PrepareMsgType, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4})))
+		require.NoError(t, err)
+	}
+	var decided *qbft.SignedMessage
+	for _, id := range []types.OperatorID{1, 2, 3} {
+		decided, err = c.ProcessMsg(msg(id, qbft.CommitMsgType, testingutils.CommitDataBytes([]byte{1, 2, 3, 4})))
+		require.NoError(t, err)
+	}
+	require.NotNil(t, decided)
+}
+
+func lateCommit(c *qbft.Controller, height qbft.Height) *qbft.SignedMessage {
+	ks := testingutils.Testing4SharesSet()
+	return testingutils.SignQBFTMsg(ks.Shares[4], 4, &qbft.Message{
+		MsgType:    qbft.CommitMsgType,
+		Height:     height,
+		Round:      qbft.FirstRound,
+		Identifier: c.Identifier,
+		Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+	})
+}
+
+func TestController_LateCommits(t *testing.T) {
+	setup := func(window qbft.Height) (*qbft.Controller, *testingutils.TestingNetwork) {
+		ks := testingutils.Testing4SharesSet()
+		config := testingutils.TestingConfig(ks)
+		config.LateCommitWindow = window
+		identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+		c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+		return c, config.GetNetwork().(*testingutils.TestingNetwork)
+	}
+
+	highestSigners := func(t *testing.T, c *qbft.Controller) []types.OperatorID {
+		highest, err := c.GetConfig().GetStorage().GetHighestDecided(c.Identifier)
+		require.NoError(t, err)
+		return highest.Signers
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		c, network := setup(0)
+		decideWithQuorum(t, c)
+		broadcasted := len(network.BroadcastedMsgs)
+
+		decided, err := c.ProcessMsg(lateCommit(c, c.Height))
+		require.NoError(t, err)
+		require.Nil(t, decided)
+		require.Len(t, network.BroadcastedMsgs, broadcasted)
+		require.Len(t, highestSigners(t, c), 3)
+	})
+
+	t.Run("improved decided", func(t *testing.T) {
+		c, network := setup(1)
+		decideWithQuorum(t, c)
+		broadcasted := len(network.BroadcastedMsgs)
+
+		decided, err := c.ProcessMsg(lateCommit(c, c.Height))
+		require.NoError(t, err)
+		require.Nil(t, decided) // not decided again
+		require.Len(t, network.BroadcastedMsgs, broadcasted+1)
+		require.ElementsMatch(t, []types.OperatorID{1, 2, 3, 4}, highestSigners(t, c))
+
+		improved := &qbft.SignedMessage{}
+		require.NoError(t, improved.Decode(network.BroadcastedMsgs[broadcasted].Data))
+		require.ElementsMatch(t, []types.OperatorID{1, 2, 3, 4}, improved.Signers)
+
+		// duplicate late commit is ignored
+		_, err = c.ProcessMsg(lateCommit(c, c.Height))
+		require.NoError(t, err)
+		require.Len(t, network.BroadcastedMsgs, broadcasted+1)
+	})
+
+	t.Run("outside window", func(t *testing.T) {
+		c, network := setup(1)
+		decideWithQuorum(t, c)
+		decideWithQuorum(t, c)
+		broadcasted := len(network.BroadcastedMsgs)
+
+		_, err := c.ProcessMsg(lateCommit(c, c.Height-1))
+		require.NoError(t, err)
+		require.Len(t, network.BroadcastedMsgs, broadcasted)
+	})
+
+	t.Run("within window doesn't override higher decided", func(t *testing.T) {
+		c, network := setup(2)
+		decideWithQuorum(t, c)
+		decideWithQuorum(t, c)
+		broadcasted := len(network.BroadcastedMsgs)
+
+		_, err := c.ProcessMsg(lateCommit(c, c.Height-1))
+		require.NoError(t, err)
+		require.Len(t, network.BroadcastedMsgs, broadcasted+1)
+
+		highest, err := c.GetConfig().GetStorage().GetHighestDecided(c.Identifier)
+		require.NoError(t, err)
+		require.EqualValues(t, c.Height, highest.Message.Height)
+		require.Len(t, highest.Signers, 3)
+	})
+}
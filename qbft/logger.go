@@ -0,0 +1,57 @@
+package qbft
+
+import "github.com/bloxapp/ssv-spec/types"
+
+// LogFields are the context of a log entry, Signer is 0 if not related to a single signer msg
+type LogFields struct {
+	Identifier []byte
+	Height     Height
+	Round      Round
+	Signer     types.OperatorID
+}
+
+// Logger reports non-fatal errors, errors which don't fail the processing they occurred in (e.g. failing to broadcast a decided msg)
+type Logger interface {
+	// Error logs a non-fatal error with its context fields
+	Error(err error, fields LogFields)
+}
+
+// NopLogger discards all log entries, used if no Logger is configured
+type NopLogger struct{}
+
+// Error implements Logger
+func (l NopLogger) Error(err error, fields LogFields) {}
+
+// msgLogFields returns the log fields of msg
+func msgLogFields(msg *SignedMessage) LogFields {
+	ret := LogFields{
+		Identifier: msg.Message.Identifier,
+		Height:     msg.Message.Height,
+		Round:      msg.Message.Round,
+	}
+	if len(msg.Signers) == 1 {
+		ret.Signer = msg.Signers[0]
+	}
+	return ret
+}
+
+// logError logs a non-fatal error with the instance's identifier, height and round
+func (i *Instance) logError(err error) {
+	i.config.GetLogger().Error(err, LogFields{
+		Identifier: i.State.ID,
+		Height:     i.State.Height,
+		Round:      i.State.Round,
+	})
+}
+
+// logError logs a non-fatal error with the msg's fields, or the controller's identifier and height if msg is nil
+func (c *Controller) logError(err error, msg *SignedMessage) {
+	fields := LogFields{
+		Identifier: c.Identifier,
+		Height:     c.Height,
+	}
+	if msg != nil {
+		fields = msgLogFields(msg)
+	}
+	c.GetConfig().GetLogger().Error(err, fields)
+}
@@ -0,0 +1,67 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type failingControllerStorage struct{}
+
+func (s *failingControllerStorage) SaveControllerSnapshot(identifier []byte, data []byte) error {
+	return errors.New("storage failure")
+}
+
+func (s *failingControllerStorage) GetControllerSnapshot(identifier []byte) ([]byte, error) {
+	return nil, nil
+}
+
+func TestConfig_GetLogger(t *testing.T) {
+	require.EqualValues(t, qbft.NopLogger{}, (&qbft.Config{}).GetLogger())
+
+	logger := testingutils.NewTestingLogger()
+	require.EqualValues(t, logger, (&qbft.Config{Logger: logger}).GetLogger())
+}
+
+func TestController_LogsNonFatalErrors(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	config := testingutils.TestingConfig(ks)
+	config.HistoricalInstanceCapacity = 1
+	config.InstanceEvictionF = func(instance *qbft.Instance) error {
+		return errors.New("eviction failure")
+	}
+	logger := config.Logger.(*testingutils.TestingLogger)
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+
+	decideWithQuorum(t, c)
+	require.Len(t, logger.Entries, 0)
+
+	t.Run("eviction failure", func(t *testing.T) {
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.EqualValues(t, []string{"eviction failure"}, logger.Errors())
+		require.EqualValues(t, qbft.LogFields{
+			Identifier: identifier[:],
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+		}, logger.Entries[0].Fields)
+	})
+
+	t.Run("checkpoint failure", func(t *testing.T) {
+		config.ControllerStorage = &failingControllerStorage{}
+		msg := lateCommit(c, c.Height)
+		_, err := c.ProcessMsg(msg)
+		require.NoError(t, err)
+		require.Len(t, logger.Entries, 2)
+		require.EqualValues(t, "could not save controller snapshot: storage failure", logger.Entries[1].Err)
+		require.EqualValues(t, qbft.LogFields{
+			Identifier: identifier[:],
+			Height:     1,
+			Round:      qbft.FirstRound,
+			Signer:     4,
+		}, logger.Entries[1].Fields)
+	})
+}
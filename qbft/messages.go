@@ -61,6 +61,12 @@ func (d *ProposalData) Validate() error {
 	if len(d.Data) == 0 {
 		return errors.New("ProposalData data is invalid")
 	}
+	if len(d.RoundChangeJustification) > MaxJustificationMsgs {
+		return errors.New("ProposalData round change justification exceeds max msgs")
+	}
+	if len(d.PrepareJustification) > MaxJustificationMsgs {
+		return errors.New("ProposalData prepare justification exceeds max msgs")
+	}
 	return nil
 }
 
@@ -136,6 +142,9 @@ func (d *RoundChangeData) Decode(data []byte) error {
 // Validate returns error if msg validation doesn't pass.
 // Msg validation checks the msg, it's variables for validity.
 func (d *RoundChangeData) Validate() error {
+	if len(d.RoundChangeJustification) > MaxJustificationMsgs {
+		return errors.New("round change justification exceeds max msgs")
+	}
 	if d.Prepared() {
 		if len(d.PreparedValue) == 0 {
 			return errors.New("round change prepared value invalid")
@@ -156,37 +165,37 @@ type Message struct {
 	Data       []byte
 }
 
-// GetProposalData returns proposal specific data
+// GetProposalData returns proposal specific data, decoded with the version it was encoded with
 func (msg *Message) GetProposalData() (*ProposalData, error) {
 	ret := &ProposalData{}
-	if err := ret.Decode(msg.Data); err != nil {
+	if err := ret.DecodeWithVersion(detectEncodingVersion(msg.Data), msg.Data); err != nil {
 		return nil, errors.Wrap(err, "could not decode proposal data from message")
 	}
 	return ret, nil
 }
 
-// GetPrepareData returns prepare specific data
+// GetPrepareData returns prepare specific data, decoded with the version it was encoded with
 func (msg *Message) GetPrepareData() (*PrepareData, error) {
 	ret := &PrepareData{}
-	if err := ret.Decode(msg.Data); err != nil {
+	if err := ret.DecodeWithVersion(detectEncodingVersion(msg.Data), msg.Data); err != nil {
 		return nil, errors.Wrap(err, "could not decode prepare data from message")
 	}
 	return ret, nil
 }
 
-// GetCommitData returns commit specific data
+// GetCommitData returns commit specific data, decoded with the version it was encoded with
 func (msg *Message) GetCommitData() (*CommitData, error) {
 	ret := &CommitData{}
-	if err := ret.Decode(msg.Data); err != nil {
+	if err := ret.DecodeWithVersion(detectEncodingVersion(msg.Data), msg.Data); err != nil {
 		return nil, errors.Wrap(err, "could not decode commit data from message")
 	}
 	return ret, nil
 }
 
-// GetRoundChangeData returns round change specific data
+// GetRoundChangeData returns round change specific data, decoded with the version it was encoded with
 func (msg *Message) GetRoundChangeData() (*RoundChangeData, error) {
 	ret := &RoundChangeData{}
-	if err := ret.Decode(msg.Data); err != nil {
+	if err := ret.DecodeWithVersion(detectEncodingVersion(msg.Data), msg.Data); err != nil {
 		return nil, errors.Wrap(err, "could not decode round change data from message")
 	}
 	return ret, nil
@@ -231,6 +240,9 @@ type SignedMessage struct {
 	Signature types.Signature
 	Signers   []types.OperatorID
 	Message   *Message // message for which this signature is for
+
+	// verifiedDigest is the digest of the signature, signers, msg root, domain and operators verified ahead of processing (see markSignatureVerified), a mutated msg no longer matches it
+	verifiedDigest []byte
 }
 
 func (signedMsg *SignedMessage) GetSignature() types.Signature {
@@ -306,8 +318,11 @@ func (signedMsg *SignedMessage) Encode() ([]byte, error) {
 	return json.Marshal(signedMsg)
 }
 
-// Decode returns error if decoding failed
+// Decode returns error if decoding failed, data can be encoded with any EncodingVersion
 func (signedMsg *SignedMessage) Decode(data []byte) error {
+	if detectEncodingVersion(data) == SSZEncodingVersion {
+		return signedMsg.DecodeWithVersion(SSZEncodingVersion, data)
+	}
 	return json.Unmarshal(data, &signedMsg)
 }
 
@@ -0,0 +1,100 @@
+package qbft
+
+import (
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+// MisbehaviorType is the type of a Misbehavior
+type MisbehaviorType string
+
+const (
+	// InvalidMsgMisbehavior is a malformed msg (failing SignedMessage.Validate)
+	InvalidMsgMisbehavior MisbehaviorType = "InvalidMsg"
+	// InvalidSignatureMisbehavior is a msg with an invalid signature or signers not in the committee
+	InvalidSignatureMisbehavior MisbehaviorType = "InvalidSignature"
+	// RateLimitedMisbehavior is a msg exceeding the config's RateLimit
+	RateLimitedMisbehavior MisbehaviorType = "RateLimited"
+	// RejectedMsgMisbehavior is a validly signed msg rejected by its instance for content no honest operator signs (e.g. a proposal from a non leader or with an invalid justification)
+	RejectedMsgMisbehavior MisbehaviorType = "RejectedMsg"
+	// EquivocationMisbehavior is an operator signing conflicting msgs (see EquivocationEvidence)
+	EquivocationMisbehavior MisbehaviorType = "Equivocation"
+)
+
+// Misbehavior is a msg rejected by a controller as invalid or exceeding its signer's rate limit.
+// The signers of msgs rejected before their signature was verified (malformed, invalidly signed and rate limited msgs) are not proven, anyone can claim them.
+// Such misbehavior is not Attributed and should be attributed to the peer that relayed the msg rather than to its signers.
+// Rejected msgs and equivocations are reported only once their signature was verified, attributing them to their signers
+type Misbehavior struct {
+	Type       MisbehaviorType
+	Identifier []byte
+	Height     Height
+	Round      Round
+	Signers    []types.OperatorID
+	// Attributed is true if the msg's signature was verified, proving Signers signed the msg
+	Attributed bool
+	Msg        *SignedMessage
+	Reason     error
+	// Evidence is the equivocation evidence of an EquivocationMisbehavior, nil otherwise
+	Evidence *EquivocationEvidence
+}
+
+// MisbehaviorF is called with every misbehavior a controller detects, e.g. for scoring peers (see p2p/SCORING.md)
+type MisbehaviorF func(misbehavior *Misbehavior)
+
+// provenMisbehaviorError marks a msg rejection proving its (verified) signer misbehaved, as opposed to rejections honest operators cause (e.g. msgs arriving late)
+type provenMisbehaviorError struct {
+	error
+}
+
+// provenMisbehavior marks err as a rejection proving misbehavior, keeping its error string
+func provenMisbehavior(err error) error {
+	return &provenMisbehaviorError{error: err}
+}
+
+// isProvenMisbehavior returns true if err or any of its causes was marked by provenMisbehavior
+func isProvenMisbehavior(err error) bool {
+	for err != nil {
+		if _, ok := err.(*provenMisbehaviorError); ok {
+			return true
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return false
+		}
+		err = cause.Cause()
+	}
+	return false
+}
+
+// reportMisbehavior passes a misbehavior for msg to the config's MisbehaviorF, if set
+func (c *Controller) reportMisbehavior(misbehaviorType MisbehaviorType, msg *SignedMessage, reason error) {
+	if f := c.GetConfig().GetMisbehaviorF(); f != nil {
+		f(c.newMisbehavior(misbehaviorType, msg, reason))
+	}
+}
+
+// reportEquivocation passes an EquivocationMisbehavior for evidence to the config's MisbehaviorF, if set
+func (c *Controller) reportEquivocation(evidence *EquivocationEvidence) {
+	if f := c.GetConfig().GetMisbehaviorF(); f != nil {
+		misbehavior := c.newMisbehavior(EquivocationMisbehavior, evidence.SecondMsg, errors.New("conflicting msgs signed"))
+		misbehavior.Evidence = evidence
+		f(misbehavior)
+	}
+}
+
+func (c *Controller) newMisbehavior(misbehaviorType MisbehaviorType, msg *SignedMessage, reason error) *Misbehavior {
+	misbehavior := &Misbehavior{
+		Type:       misbehaviorType,
+		Identifier: c.Identifier,
+		Signers:    msg.GetSigners(),
+		Attributed: msg.isSignatureVerified(c.GetConfig(), c.Share.Committee),
+		Msg:        msg,
+		Reason:     reason,
+	}
+	if msg.Message != nil {
+		misbehavior.Height = msg.Message.Height
+		misbehavior.Round = msg.Message.Round
+	}
+	return misbehavior
+}
@@ -0,0 +1,78 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestController_MisbehaviorAttribution(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	setup := func() (*qbft.Controller, *[]*qbft.Misbehavior) {
+		misbehaviors := make([]*qbft.Misbehavior, 0)
+		config := testingutils.TestingConfig(ks)
+		config.MisbehaviorF = func(misbehavior *qbft.Misbehavior) {
+			misbehaviors = append(misbehaviors, misbehavior)
+		}
+		c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		return c, &misbehaviors
+	}
+	msg := func(id types.OperatorID, msgType qbft.MessageType, data []byte) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+			MsgType:    msgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: identifier[:],
+			Data:       data,
+		})
+	}
+
+	t.Run("proposal from non leader", func(t *testing.T) {
+		c, misbehaviors := setup()
+		_, err := c.ProcessMsg(msg(2, qbft.ProposalMsgType, testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil)))
+		require.EqualError(t, err, "could not process msg: proposal invalid: proposal leader invalid")
+		require.Len(t, *misbehaviors, 1)
+		require.EqualValues(t, qbft.RejectedMsgMisbehavior, (*misbehaviors)[0].Type)
+		require.EqualValues(t, []types.OperatorID{2}, (*misbehaviors)[0].Signers)
+		require.True(t, (*misbehaviors)[0].Attributed)
+	})
+
+	t.Run("forged proposal from non leader", func(t *testing.T) {
+		c, misbehaviors := setup()
+		forged := msg(2, qbft.ProposalMsgType, testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil))
+		forged.Signature = msg(3, qbft.ProposalMsgType, testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil)).Signature
+		_, err := c.ProcessMsg(forged)
+		require.EqualError(t, err, "invalid msg: msg signature invalid: failed to verify signature")
+		require.Len(t, *misbehaviors, 1)
+		require.EqualValues(t, qbft.InvalidSignatureMisbehavior, (*misbehaviors)[0].Type)
+		require.False(t, (*misbehaviors)[0].Attributed)
+	})
+
+	t.Run("honest rejection not reported", func(t *testing.T) {
+		c, misbehaviors := setup()
+		// a prepare arriving before its proposal
+		_, err := c.ProcessMsg(msg(2, qbft.PrepareMsgType, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4})))
+		require.EqualError(t, err, "could not process msg: no proposal accepted for prepare")
+		require.Len(t, *misbehaviors, 0)
+	})
+
+	t.Run("equivocation", func(t *testing.T) {
+		c, misbehaviors := setup()
+		_, err := c.ProcessMsg(msg(1, qbft.ProposalMsgType, testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil)))
+		require.NoError(t, err)
+		_, err = c.ProcessMsg(msg(2, qbft.PrepareMsgType, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4})))
+		require.NoError(t, err)
+		_, _ = c.ProcessMsg(msg(2, qbft.PrepareMsgType, testingutils.PrepareDataBytes([]byte{5, 6, 7, 8})))
+
+		require.Len(t, *misbehaviors, 1)
+		require.EqualValues(t, qbft.EquivocationMisbehavior, (*misbehaviors)[0].Type)
+		require.EqualValues(t, []types.OperatorID{2}, (*misbehaviors)[0].Signers)
+		require.True(t, (*misbehaviors)[0].Attributed)
+		require.NotNil(t, (*misbehaviors)[0].Evidence)
+		require.NoError(t, (*misbehaviors)[0].Evidence.Validate(types.PrimusTestnet, ks.Committee()))
+	})
+}
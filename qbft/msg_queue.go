@@ -0,0 +1,266 @@
+package qbft
+
+import (
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+// DefaultMsgQueueCapacity is the max number of msgs buffered by a MsgQueue if no capacity is configured
+const DefaultMsgQueueCapacity = 256
+
+// DefaultMsgQueueMaxPerSignerHeight is the default max number of msgs buffered per signer and height (see MsgQueue.MaxPerSignerHeight)
+const DefaultMsgQueueMaxPerSignerHeight = 32
+
+// DefaultMsgQueueMaxHeightsAhead is the default max number of heights a buffered msg can be ahead of the controller's height (see MsgQueue.MaxHeightsAhead)
+const DefaultMsgQueueMaxHeightsAhead Height = 2
+
+const (
+	decidedMsgPriority = iota
+	commitMsgPriority
+	defaultMsgPriority
+)
+
+type queuedMsg struct {
+	msg      *SignedMessage
+	priority int
+}
+
+// MsgQueue buffers msgs in front of a Controller, processing each msg only once the controller can act upon it:
+// - future height msgs wait for the controller to start an instance for their height
+// - prepare msgs wait for their instance to reach their round and accept a proposal for it
+// - msgs for heights no longer stored by the controller or stopped instances, or prepares for decided instances or rounds the instance already left, are dropped
+// Ready msgs are processed by priority, decided msgs first, then commits, then all others, each in arrival order.
+// Decided, proposal, commit and round change msgs are always ready as the controller/ instance acts upon them in any round.
+// A single signer can't fill the queue, msgs are bounded per signer and height and can't be too far ahead of the controller's height.
+// A full queue evicts its lowest priority (farthest height among equal priorities) msg for a msg ranking higher.
+// The queue doesn't replay msgs on its own, ProcessReady must be called after pushing msgs and after every controller/ instance change (see ProcessReady).
+// The queue is guarded by its controller's lock, so it's safe to use concurrently with the controller's entry points (e.g. UponRoundTimeout called by timer goroutines).
+type MsgQueue struct {
+	// MaxPerSignerHeight is the max number of msgs buffered per signer (the first signer of multi signer msgs) and height
+	MaxPerSignerHeight int
+	// MaxHeightsAhead is the max number of heights a (non decided) msg can be ahead of the controller's height
+	MaxHeightsAhead Height
+
+	controller *Controller
+	capacity   int
+	msgs       []*queuedMsg
+}
+
+// NewMsgQueue returns an empty queue for controller buffering up to capacity msgs (DefaultMsgQueueCapacity if capacity < 1)
+func NewMsgQueue(controller *Controller, capacity int) *MsgQueue {
+	if capacity < 1 {
+		capacity = DefaultMsgQueueCapacity
+	}
+	return &MsgQueue{
+		MaxPerSignerHeight: DefaultMsgQueueMaxPerSignerHeight,
+		MaxHeightsAhead:    DefaultMsgQueueMaxHeightsAhead,
+		controller:         controller,
+		capacity:           capacity,
+		msgs:               make([]*queuedMsg, 0),
+	}
+}
+
+// Len returns the number of buffered msgs
+func (q *MsgQueue) Len() int {
+	q.controller.lock.Lock()
+	defer q.controller.lock.Unlock()
+
+	return len(q.msgs)
+}
+
+// Push buffers msg, returns error if msg is invalid, stale, too far ahead, above its signer's bound or the queue is full (and no lower ranking msg can be evicted).
+// Future height msgs are tracked by the controller to trigger a sync of higher heights (as if processed by it), their signature is verified only if within the config's RateLimit
+func (q *MsgQueue) Push(msg *SignedMessage) error {
+	if msg == nil {
+		return errors.New("msg is nil")
+	}
+	if err := msg.Validate(); err != nil {
+		return errors.Wrap(err, "invalid msg")
+	}
+
+	q.controller.lock.Lock()
+	defer q.controller.lock.Unlock()
+
+	if err := q.controller.baseMsgValidation(msg); err != nil {
+		return errors.Wrap(err, "invalid msg")
+	}
+	if q.isStale(msg) {
+		return errors.New("stale msg")
+	}
+	if q.isFutureHeight(msg) && msg.Message.Height-q.controller.Height > q.MaxHeightsAhead {
+		return errors.New("msg too far ahead")
+	}
+	if q.countSignerHeight(msg) >= q.MaxPerSignerHeight {
+		return errors.New("too many msgs for signer and height")
+	}
+	priority := q.msgPriority(msg)
+	if len(q.msgs) >= q.capacity && !q.evictFor(msg, priority) {
+		return errors.New("msg queue full")
+	}
+
+	if q.isFutureHeight(msg) {
+		// rate limited before verified, counted once processed by the controller
+		if _, _, err := q.controller.screenMsgLimits(msg, nil); err != nil {
+			return errors.Wrap(err, "invalid msg")
+		}
+		if err := validateFutureMsg(q.controller.GetConfig(), msg, q.controller.Share.Committee); err != nil {
+			return errors.Wrap(err, "invalid future msg")
+		}
+		if q.controller.addHigherHeightMsg(msg) && q.controller.f1SyncTrigger() {
+			if err := q.controller.GetConfig().GetNetwork().SyncHighestDecided(types.MessageIDFromBytes(q.controller.Identifier)); err != nil {
+				return errors.Wrap(err, "could not sync highest decided")
+			}
+		}
+	}
+
+	q.msgs = append(q.msgs, &queuedMsg{
+		msg:      msg,
+		priority: priority,
+	})
+	return nil
+}
+
+// countSignerHeight returns the number of buffered msgs of msg's (first) signer and height
+func (q *MsgQueue) countSignerHeight(msg *SignedMessage) int {
+	ret := 0
+	for _, queued := range q.msgs {
+		if queued.msg.Message.Height == msg.Message.Height && queued.msg.Signers[0] == msg.Signers[0] {
+			ret++
+		}
+	}
+	return ret
+}
+
+// evictFor removes the lowest ranking msg (lowest priority, then farthest height, then last pushed) if msg with priority ranks higher, returns true if a msg was removed
+func (q *MsgQueue) evictFor(msg *SignedMessage, priority int) bool {
+	idx := -1
+	for i, queued := range q.msgs {
+		if idx == -1 || !ranksHigher(queued.priority, queued.msg.Message.Height, q.msgs[idx].priority, q.msgs[idx].msg.Message.Height) {
+			idx = i
+		}
+	}
+	if idx == -1 || !ranksHigher(priority, msg.Message.Height, q.msgs[idx].priority, q.msgs[idx].msg.Message.Height) {
+		return false
+	}
+
+	q.msgs = append(q.msgs[:idx], q.msgs[idx+1:]...)
+	return true
+}
+
+// ranksHigher returns true if a msg with priority and height ranks higher than a msg with otherPriority and otherHeight, nearer heights rank higher among equal priorities
+func ranksHigher(priority int, height Height, otherPriority int, otherHeight Height) bool {
+	if priority != otherPriority {
+		return priority < otherPriority
+	}
+	return height < otherHeight
+}
+
+// ProcessReady processes all ready msgs by priority with the controller, until none is ready.
+// As processing a msg can advance the instance's round or the controller's height, buffered msgs are replayed once they become ready.
+// Must be called after pushing msgs and after any other controller/ instance change (starting an instance, round timeout, sync), buffered msgs wait for the next call otherwise.
+// Msg processing errors are logged (see Config.Logger), returns the decided msgs returned by the controller
+func (q *MsgQueue) ProcessReady() []*SignedMessage {
+	q.controller.lock.Lock()
+	defer q.controller.lock.Unlock()
+
+	ret := make([]*SignedMessage, 0)
+	for {
+		q.dropStale()
+		next := q.popReady()
+		if next == nil {
+			return ret
+		}
+
+		decided, err := q.controller.processMsg(next)
+		if err != nil {
+			q.controller.logError(errors.Wrap(err, "could not process queued msg"), next)
+		}
+		if decided != nil {
+			ret = append(ret, decided)
+		}
+	}
+}
+
+// popReady removes and returns the ready msg with the highest priority (first pushed among equal priorities), nil if none ready
+func (q *MsgQueue) popReady() *SignedMessage {
+	idx := -1
+	for i, queued := range q.msgs {
+		if (idx == -1 || queued.priority < q.msgs[idx].priority) && q.isReady(queued.msg) {
+			idx = i
+		}
+	}
+	if idx == -1 {
+		return nil
+	}
+
+	ret := q.msgs[idx].msg
+	q.msgs = append(q.msgs[:idx], q.msgs[idx+1:]...)
+	return ret
+}
+
+// dropStale removes all stale msgs
+func (q *MsgQueue) dropStale() {
+	kept := make([]*queuedMsg, 0, len(q.msgs))
+	for _, queued := range q.msgs {
+		if !q.isStale(queued.msg) {
+			kept = append(kept, queued)
+		}
+	}
+	q.msgs = kept
+}
+
+func (q *MsgQueue) msgPriority(msg *SignedMessage) int {
+	if isDecidedMsg(q.controller.Share, msg) {
+		return decidedMsgPriority
+	}
+	if msg.Message.MsgType == CommitMsgType {
+		return commitMsgPriority
+	}
+	return defaultMsgPriority
+}
+
+func (q *MsgQueue) isFutureHeight(msg *SignedMessage) bool {
+	return msg.Message.Height > q.controller.Height && !isDecidedMsg(q.controller.Share, msg)
+}
+
+// isStale returns true if msg can't be processed by the controller anymore
+func (q *MsgQueue) isStale(msg *SignedMessage) bool {
+	if isDecidedMsg(q.controller.Share, msg) || q.isFutureHeight(msg) {
+		return false
+	}
+
+	inst := q.controller.InstanceForHeight(msg.Message.Height)
+	if inst == nil {
+		return msg.Message.Height < q.controller.Height
+	}
+	if inst.IsStopped() {
+		return true
+	}
+
+	// prepares for a round the instance left (or for a decided instance) can't be processed, commits of any round can form a commit quorum
+	if msg.Message.MsgType != PrepareMsgType {
+		return false
+	}
+	decided, _ := inst.IsDecided()
+	return decided || msg.Message.Round < inst.State.Round
+}
+
+// isReady returns true if the controller can act upon msg
+func (q *MsgQueue) isReady(msg *SignedMessage) bool {
+	if isDecidedMsg(q.controller.Share, msg) {
+		return true
+	}
+	if q.isFutureHeight(msg) {
+		return false
+	}
+
+	inst := q.controller.InstanceForHeight(msg.Message.Height)
+	if inst == nil {
+		return true
+	}
+	if msg.Message.MsgType != PrepareMsgType {
+		return true
+	}
+	return msg.Message.Round < inst.State.Round ||
+		(msg.Message.Round == inst.State.Round && inst.State.ProposalAcceptedForCurrentRound != nil)
+}
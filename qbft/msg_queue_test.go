@@ -0,0 +1,210 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+func TestMsgQueue(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	setup := func(historicalCapacity int) (*qbft.Controller, *testingutils.TestingLogger) {
+		config := testingutils.TestingConfig(ks)
+		config.HistoricalInstanceCapacity = historicalCapacity
+		identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+		return testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config), config.Logger.(*testingutils.TestingLogger)
+	}
+	msg := func(c *qbft.Controller, id types.OperatorID, msgType qbft.MessageType, height qbft.Height, round qbft.Round, data []byte) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+			MsgType:    msgType,
+			Height:     height,
+			Round:      round,
+			Identifier: c.Identifier,
+			Data:       data,
+		})
+	}
+	// instanceMsgs returns commits, prepares and a proposal (reversed order) for height and the first round
+	instanceMsgs := func(c *qbft.Controller, height qbft.Height) []*qbft.SignedMessage {
+		ret := make([]*qbft.SignedMessage, 0)
+		for _, id := range []types.OperatorID{1, 2, 3} {
+			ret = append(ret, msg(c, id, qbft.CommitMsgType, height, qbft.FirstRound, testingutils.CommitDataBytes([]byte{1, 2, 3, 4})))
+		}
+		for _, id := range []types.OperatorID{1, 2, 3} {
+			ret = append(ret, msg(c, id, qbft.PrepareMsgType, height, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4})))
+		}
+		return append(ret, msg(c, 1, qbft.ProposalMsgType, height, qbft.FirstRound, testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil)))
+	}
+
+	t.Run("replay future height", func(t *testing.T) {
+		c, logger := setup(0)
+		q := qbft.NewMsgQueue(c, 0)
+		for _, m := range instanceMsgs(c, qbft.FirstHeight) {
+			require.NoError(t, q.Push(m))
+		}
+		require.Len(t, q.ProcessReady(), 0)
+		require.EqualValues(t, 7, q.Len())
+
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		decided := q.ProcessReady()
+		require.Len(t, decided, 1)
+		require.EqualValues(t, 0, q.Len())
+		require.Empty(t, logger.Errors())
+
+		isDecided, _ := c.InstanceForHeight(qbft.FirstHeight).IsDecided()
+		require.True(t, isDecided)
+	})
+
+	t.Run("future round", func(t *testing.T) {
+		c, logger := setup(0)
+		q := qbft.NewMsgQueue(c, 0)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+
+		require.NoError(t, q.Push(msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight, 2, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+		require.NoError(t, q.Push(msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+		q.ProcessReady()
+		require.EqualValues(t, 2, q.Len()) // no proposal accepted yet
+
+		require.NoError(t, c.UponRoundTimeout(qbft.FirstHeight, qbft.FirstRound))
+		q.ProcessReady()
+		require.EqualValues(t, 1, q.Len()) // first round prepare dropped
+		require.Empty(t, logger.Errors())
+	})
+
+	t.Run("stale height", func(t *testing.T) {
+		c, _ := setup(1)
+		q := qbft.NewMsgQueue(c, 0)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		for _, m := range instanceMsgs(c, qbft.FirstHeight) {
+			require.NoError(t, q.Push(m))
+		}
+		require.Len(t, q.ProcessReady(), 1)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+
+		require.EqualError(t, q.Push(msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))), "stale msg")
+	})
+
+	t.Run("full", func(t *testing.T) {
+		c, _ := setup(0)
+		q := qbft.NewMsgQueue(c, 1)
+		msgs := instanceMsgs(c, qbft.FirstHeight)
+		require.NoError(t, q.Push(msgs[0]))
+		require.EqualError(t, q.Push(msgs[1]), "msg queue full")
+	})
+
+	t.Run("full evicts lower ranking", func(t *testing.T) {
+		c, _ := setup(0)
+		q := qbft.NewMsgQueue(c, 2)
+		// not started, all msgs are future height msgs
+		require.NoError(t, q.Push(msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight+1, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+		require.NoError(t, q.Push(msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+
+		// a nearer height prepare evicts the farther one
+		require.NoError(t, q.Push(msg(c, 3, qbft.PrepareMsgType, qbft.FirstHeight, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+		require.EqualValues(t, 2, q.Len())
+
+		// a commit evicts a prepare
+		require.NoError(t, q.Push(msg(c, 2, qbft.CommitMsgType, qbft.FirstHeight+1, qbft.FirstRound, testingutils.CommitDataBytes([]byte{1, 2, 3, 4}))))
+		require.EqualValues(t, 2, q.Len())
+
+		// a farther prepare evicts nothing
+		require.EqualError(t, q.Push(msg(c, 4, qbft.PrepareMsgType, qbft.FirstHeight+1, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))), "msg queue full")
+	})
+
+	t.Run("too far ahead", func(t *testing.T) {
+		c, _ := setup(0)
+		q := qbft.NewMsgQueue(c, 0)
+		q.MaxHeightsAhead = 1
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.NoError(t, q.Push(msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight+1, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+		require.EqualError(t, q.Push(msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight+2, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))), "msg too far ahead")
+	})
+
+	t.Run("signer and height bound", func(t *testing.T) {
+		c, _ := setup(0)
+		q := qbft.NewMsgQueue(c, 0)
+		q.MaxPerSignerHeight = 2
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		for round := qbft.Round(2); round < 4; round++ {
+			require.NoError(t, q.Push(msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight, round, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+		}
+		require.EqualError(t, q.Push(msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight, 4, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))), "too many msgs for signer and height")
+
+		// other signers and heights have their own bound
+		require.NoError(t, q.Push(msg(c, 3, qbft.PrepareMsgType, qbft.FirstHeight, 4, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+		require.NoError(t, q.Push(msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight+1, 4, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+	})
+
+	t.Run("no signers", func(t *testing.T) {
+		c, _ := setup(0)
+		q := qbft.NewMsgQueue(c, 0)
+		require.NoError(t, q.Push(msg(c, 1, qbft.PrepareMsgType, qbft.FirstHeight, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+		m := msg(c, 2, qbft.PrepareMsgType, qbft.FirstHeight, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))
+		m.Signers = []types.OperatorID{}
+		require.EqualError(t, q.Push(m), "invalid msg: message signers is empty")
+	})
+
+	t.Run("future msgs rate limited before verified", func(t *testing.T) {
+		config := testingutils.TestingConfig(ks)
+		config.RateLimit = &qbft.RateLimit{MaxRoundsAhead: 1}
+		identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+		c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+		q := qbft.NewMsgQueue(c, 0)
+
+		// forged, rejected by the rate limit rather than by its signature
+		m := msg(c, 2, qbft.RoundChangeMsgType, qbft.FirstHeight, 5, testingutils.RoundChangeDataBytes(nil, qbft.NoRound))
+		m.Signature = msg(c, 3, qbft.RoundChangeMsgType, qbft.FirstHeight, 5, testingutils.RoundChangeDataBytes(nil, qbft.NoRound)).Signature
+		require.EqualError(t, q.Push(m), "invalid msg: msg rate limited: round too far ahead")
+		require.EqualValues(t, 0, q.Len())
+	})
+
+	t.Run("replayed only by ProcessReady", func(t *testing.T) {
+		c, _ := setup(0)
+		q := qbft.NewMsgQueue(c, 0)
+		for _, m := range instanceMsgs(c, qbft.FirstHeight) {
+			require.NoError(t, q.Push(m))
+		}
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.EqualValues(t, 7, q.Len()) // starting an instance doesn't replay
+
+		require.Len(t, q.ProcessReady(), 1)
+		require.EqualValues(t, 0, q.Len())
+	})
+
+	t.Run("concurrent with round timeouts", func(t *testing.T) {
+		c, _ := setup(0)
+		q := qbft.NewMsgQueue(c, 0)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for round := qbft.FirstRound; round < 10; round++ {
+				require.NoError(t, c.UponRoundTimeout(qbft.FirstHeight, round))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for height := qbft.FirstHeight + 1; height < 3; height++ {
+				for _, id := range []types.OperatorID{2, 3, 4} {
+					require.NoError(t, q.Push(msg(c, id, qbft.PrepareMsgType, height, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))))
+				}
+				q.ProcessReady()
+			}
+		}()
+		wg.Wait()
+		require.EqualValues(t, 6, q.Len()) // future heights wait for their instance
+		require.EqualValues(t, 10, c.InstanceForHeight(qbft.FirstHeight).State.Round)
+	})
+
+	t.Run("invalid msg", func(t *testing.T) {
+		c, _ := setup(0)
+		q := qbft.NewMsgQueue(c, 0)
+		m := msg(c, 1, qbft.PrepareMsgType, qbft.FirstHeight, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}))
+		m.Message.Identifier = []byte{1, 2, 3, 5}
+		require.EqualError(t, q.Push(m), "invalid msg: message doesn't belong to Identifier")
+	})
+}
@@ -0,0 +1,60 @@
+package qbft
+
+// EventType is the type of an instance lifecycle Event
+type EventType string
+
+const (
+	InstanceStartedEvent  EventType = "InstanceStarted"
+	ProposalAcceptedEvent EventType = "ProposalAccepted"
+	PreparedEvent         EventType = "Prepared"
+	RoundChangedEvent     EventType = "RoundChanged"
+	DecidedEvent          EventType = "Decided"
+	MessageRejectedEvent  EventType = "MessageRejected"
+	InstanceStoppedEvent  EventType = "InstanceStopped"
+)
+
+// Event is an instance lifecycle event passed to the config's Observer
+type Event struct {
+	Type       EventType
+	Identifier []byte
+	Height     Height
+	// Round is the instance's round after the event
+	Round Round
+	// Msg is the msg which triggered the event, nil for events not triggered by a msg (e.g. round timeout)
+	Msg *SignedMessage
+	// Value is the start, proposed, prepared or decided value (depending on the event type)
+	Value []byte
+	// Reason is the reason a msg was rejected, set for MessageRejectedEvent only
+	Reason error
+}
+
+// Observer receives instance lifecycle events, e.g. for building metrics and logs.
+// OnEvent is called synchronously while processing msgs and should not block.
+type Observer interface {
+	OnEvent(event *Event)
+}
+
+// ObserverF is a func implementing Observer
+type ObserverF func(event *Event)
+
+// OnEvent calls f with event
+func (f ObserverF) OnEvent(event *Event) {
+	f(event)
+}
+
+// emit passes an event for the instance's current state to the config's Observer, if set
+func (i *Instance) emit(eventType EventType, msg *SignedMessage, value []byte, reason error) {
+	observer := i.config.GetObserver()
+	if observer == nil {
+		return
+	}
+	observer.OnEvent(&Event{
+		Type:       eventType,
+		Identifier: i.State.ID,
+		Height:     i.State.Height,
+		Round:      i.State.Round,
+		Msg:        msg,
+		Value:      value,
+		Reason:     reason,
+	})
+}
@@ -0,0 +1,88 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInstance_Observer(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	msg := func(id types.OperatorID, msgType qbft.MessageType, round qbft.Round, data []byte) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+			MsgType:    msgType,
+			Height:     qbft.FirstHeight,
+			Round:      round,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       data,
+		})
+	}
+	setup := func() (*qbft.Instance, *testingutils.TestingObserver) {
+		observer := testingutils.NewTestingObserver()
+		inst := testingutils.BaseInstance()
+		inst.GetConfig().(*qbft.Config).Observer = observer
+		return inst, observer
+	}
+
+	t.Run("decided", func(t *testing.T) {
+		inst, observer := setup()
+		inst.Start([]byte{1, 2, 3, 4}, qbft.FirstHeight)
+
+		_, _, _, err := inst.ProcessMsg(msg(1, qbft.ProposalMsgType, qbft.FirstRound, testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil)))
+		require.NoError(t, err)
+		for _, id := range []types.OperatorID{1, 2, 3} {
+			_, _, _, err := inst.ProcessMsg(msg(id, qbft.PrepareMsgType, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4})))
+			require.NoError(t, err)
+		}
+		for _, id := range []types.OperatorID{1, 2, 3, 4} {
+			_, _, _, err := inst.ProcessMsg(msg(id, qbft.CommitMsgType, qbft.FirstRound, testingutils.CommitDataBytes([]byte{1, 2, 3, 4})))
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, []qbft.EventType{
+			qbft.InstanceStartedEvent,
+			qbft.ProposalAcceptedEvent,
+			qbft.PreparedEvent,
+			qbft.DecidedEvent,
+		}, observer.EventTypes())
+
+		decided := observer.Events[3]
+		require.EqualValues(t, []byte{1, 2, 3, 4}, decided.Value)
+		require.EqualValues(t, []byte{1, 2, 3, 4}, decided.Identifier)
+		require.EqualValues(t, qbft.FirstHeight, decided.Height)
+		require.EqualValues(t, qbft.FirstRound, decided.Round)
+		require.EqualValues(t, []types.OperatorID{3}, decided.Msg.Signers)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		inst, observer := setup()
+
+		_, _, _, err := inst.ProcessMsg(msg(1, qbft.PrepareMsgType, qbft.FirstRound, testingutils.PrepareDataBytes([]byte{1, 2, 3, 4})))
+		require.EqualError(t, err, "no proposal accepted for prepare")
+		require.EqualValues(t, []qbft.EventType{qbft.MessageRejectedEvent}, observer.EventTypes())
+		require.EqualError(t, observer.Events[0].Reason, "no proposal accepted for prepare")
+	})
+
+	t.Run("round changed", func(t *testing.T) {
+		inst, observer := setup()
+		inst.Start([]byte{1, 2, 3, 4}, qbft.FirstHeight)
+
+		require.NoError(t, inst.ProcessTimeout(qbft.FirstRound))
+		for _, id := range []types.OperatorID{2, 3} {
+			_, _, _, err := inst.ProcessMsg(msg(id, qbft.RoundChangeMsgType, 5, testingutils.RoundChangeDataBytes(nil, qbft.NoRound)))
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, []qbft.EventType{
+			qbft.InstanceStartedEvent,
+			qbft.RoundChangedEvent,
+			qbft.RoundChangedEvent,
+		}, observer.EventTypes())
+		require.EqualValues(t, 2, observer.Events[1].Round)
+		require.Nil(t, observer.Events[1].Msg)
+		require.EqualValues(t, 5, observer.Events[2].Round)
+		require.NotNil(t, observer.Events[2].Msg)
+	})
+}
@@ -49,6 +49,7 @@ func (i *Instance) uponPrepare(
 
 	i.State.LastPreparedValue = proposedValue
 	i.State.LastPreparedRound = i.State.Round
+	i.emit(PreparedEvent, signedPrepare, proposedValue, nil)
 
 	commitMsg, err := CreateCommit(i.State, i.config, proposedValue)
 	if err != nil {
@@ -62,9 +63,9 @@ func (i *Instance) uponPrepare(
 	return nil
 }
 
-func getRoundChangeJustification(state *State, config IConfig, prepareMsgContainer *MsgContainer) []*SignedMessage {
+func getRoundChangeJustification(state *State, config IConfig, prepareMsgContainer *MsgContainer) ([]*SignedMessage, error) {
 	if state.LastPreparedValue == nil {
-		return nil
+		return nil, nil
 	}
 
 	prepareMsgs := prepareMsgContainer.MessagesForRound(state.LastPreparedRound)
@@ -74,32 +75,9 @@ func getRoundChangeJustification(state *State, config IConfig, prepareMsgContain
 			ret = append(ret, msg)
 		}
 	}
-	return ret
+	return compactPrepareJustification(ret)
 }
 
-// validPreparesForHeightRoundAndValue returns an aggregated prepare msg for a specific Height and round
-//func validPreparesForHeightRoundAndValue(
-//	config IConfig,
-//	prepareMessages []*SignedMessage,
-//	height Height,
-//	round Round,
-//	value []byte,
-//	operators []*types.Operator) *SignedMessage {
-//	var aggregatedPrepareMsg *SignedMessage
-//	for _, signedMsg := range prepareMessages {
-//		if err := validSignedPrepareForHeightRoundAndValue(config, signedMsg, height, round, value, operators); err == nil {
-//			if aggregatedPrepareMsg == nil {
-//				aggregatedPrepareMsg = signedMsg
-//			} else {
-//				// TODO: check error
-//				// nolint
-//				aggregatedPrepareMsg.Aggregate(signedMsg)
-//			}
-//		}
-//	}
-//	return aggregatedPrepareMsg
-//}
-
 // validSignedPrepareForHeightRoundAndValue known in dafny spec as validSignedPrepareForHeightRoundAndDigest
 // https://entethalliance.github.io/client-spec/qbft_spec.html#dfn-qbftspecification
 func validSignedPrepareForHeightRoundAndValue(
@@ -109,6 +87,46 @@ func validSignedPrepareForHeightRoundAndValue(
 	round Round,
 	value []byte,
 	operators []*types.Operator) error {
+	if err := validPrepareForHeightRoundAndValue(signedPrepare, height, round, value); err != nil {
+		return err
+	}
+
+	if len(signedPrepare.GetSigners()) != 1 {
+		return errors.New("prepare msg allows 1 signer")
+	}
+
+	if err := verifyMsgSignature(config, signedPrepare, operators); err != nil {
+		return errors.Wrap(err, "prepare msg signature invalid")
+	}
+
+	return nil
+}
+
+// validSignedPrepareJustificationForHeightRoundAndValue is validSignedPrepareForHeightRoundAndValue for prepare justifications, which can be aggregated (multi signer)
+func validSignedPrepareJustificationForHeightRoundAndValue(
+	config IConfig,
+	signedPrepare *SignedMessage,
+	height Height,
+	round Round,
+	value []byte,
+	operators []*types.Operator) error {
+	if err := validPrepareForHeightRoundAndValue(signedPrepare, height, round, value); err != nil {
+		return err
+	}
+
+	if len(signedPrepare.GetSigners()) == 0 {
+		return errors.New("prepare msg has no signers")
+	}
+
+	if err := verifyMsgSignature(config, signedPrepare, operators); err != nil {
+		return errors.Wrap(err, "prepare msg signature invalid")
+	}
+
+	return nil
+}
+
+// validPrepareForHeightRoundAndValue returns nil if the prepare msg is for height, round and value
+func validPrepareForHeightRoundAndValue(signedPrepare *SignedMessage, height Height, round Round, value []byte) error {
 	if signedPrepare.Message.MsgType != PrepareMsgType {
 		return errors.New("prepare msg type is wrong")
 	}
@@ -130,15 +148,6 @@ func validSignedPrepareForHeightRoundAndValue(
 	if !bytes.Equal(prepareData.Data, value) {
 		return errors.New("prepare data != proposed data")
 	}
-
-	if len(signedPrepare.GetSigners()) != 1 {
-		return errors.New("prepare msg allows 1 signer")
-	}
-
-	if err := signedPrepare.Signature.VerifyByOperators(signedPrepare, config.GetSignatureDomainType(), types.QBFTSignatureType, operators); err != nil {
-		return errors.Wrap(err, "prepare msg signature invalid")
-	}
-
 	return nil
 }
 
@@ -158,7 +167,7 @@ func CreatePrepare(state *State, config IConfig, newRound Round, value []byte) (
 	prepareData := &PrepareData{
 		Data: value,
 	}
-	dataByts, err := prepareData.Encode()
+	dataByts, err := prepareData.EncodeWithVersion(config.GetEncodingVersion())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed encoding prepare data")
 	}
@@ -169,7 +178,7 @@ func CreatePrepare(state *State, config IConfig, newRound Round, value []byte) (
 		Identifier: state.ID,
 		Data:       dataByts,
 	}
-	sig, err := config.GetSigner().SignRoot(msg, types.QBFTSignatureType, state.Share.SharePubKey)
+	sig, err := config.GetSigner().SignRoot(msg.withVersion(config.GetEncodingVersion()), types.QBFTSignatureType, state.Share.SharePubKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed signing prepare msg")
 	}
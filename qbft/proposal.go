@@ -6,8 +6,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-func (i *Instance) uponProposal(signedProposal *SignedMessage, proposeMsgContainer *MsgContainer) error {
-	valCheck := i.config.GetValueCheckF()
+func (i *Instance) uponProposal(signedProposal *SignedMessage, proposeMsgContainer *MsgContainer, valCheck ProposedValueCheckF) error {
 	if err := isValidProposal(i.State, i.config, signedProposal, valCheck, i.State.Share.Committee); err != nil {
 		return errors.Wrap(err, "proposal invalid")
 	}
@@ -24,15 +23,20 @@ func (i *Instance) uponProposal(signedProposal *SignedMessage, proposeMsgContain
 	i.State.ProposalAcceptedForCurrentRound = signedProposal
 
 	// A future justified proposal should bump us into future round and reset timer
-	if signedProposal.Message.Round > i.State.Round {
-		i.config.GetTimer().TimeoutForRound(signedProposal.Message.Round)
+	roundChanged := signedProposal.Message.Round > i.State.Round
+	if roundChanged {
+		i.timeoutForRound(signedProposal.Message.Round)
 	}
 	i.State.Round = newRound
+	if roundChanged {
+		i.emit(RoundChangedEvent, signedProposal, nil, nil)
+	}
 
 	proposalData, err := signedProposal.Message.GetProposalData()
 	if err != nil {
 		return errors.Wrap(err, "could not get proposal data")
 	}
+	i.emit(ProposalAcceptedEvent, signedProposal, proposalData.Data, nil)
 
 	prepare, err := CreatePrepare(i.State, i.config, newRound, proposalData.Data)
 	if err != nil {
@@ -62,11 +66,11 @@ func isValidProposal(
 	if len(signedProposal.GetSigners()) != 1 {
 		return errors.New("proposal msg allows 1 signer")
 	}
-	if err := signedProposal.Signature.VerifyByOperators(signedProposal, config.GetSignatureDomainType(), types.QBFTSignatureType, operators); err != nil {
+	if err := verifyMsgSignature(config, signedProposal, operators); err != nil {
 		return errors.Wrap(err, "proposal msg signature invalid")
 	}
 	if !signedProposal.MatchedSigners([]types.OperatorID{proposer(state, config, signedProposal.Message.Round)}) {
-		return errors.New("proposal leader invalid")
+		return provenMisbehavior(errors.New("proposal leader invalid"))
 	}
 
 	proposalData, err := signedProposal.Message.GetProposalData()
@@ -74,7 +78,7 @@ func isValidProposal(
 		return errors.Wrap(err, "could not get proposal data")
 	}
 	if err := proposalData.Validate(); err != nil {
-		return errors.Wrap(err, "proposalData invalid")
+		return provenMisbehavior(errors.Wrap(err, "proposalData invalid"))
 	}
 
 	if err := isProposalJustification(
@@ -87,7 +91,7 @@ func isValidProposal(
 		proposalData.Data,
 		valCheck,
 	); err != nil {
-		return errors.Wrap(err, "proposal not justified")
+		return provenMisbehavior(errors.Wrap(err, "proposal not justified"))
 	}
 
 	if (state.ProposalAcceptedForCurrentRound == nil && signedProposal.Message.Round == state.Round) ||
@@ -174,17 +178,15 @@ func isProposalJustification(
 			}
 
 			// validate each prepare message against the highest previously prepared value and round
-			for _, pm := range prepareMsgs {
-				if err := validSignedPrepareForHeightRoundAndValue(
-					config,
-					pm,
-					height,
-					rcmData.PreparedRound,
-					rcmData.PreparedValue,
-					state.Share.Committee,
-				); err != nil {
-					return errors.New("signed prepare not valid")
-				}
+			if err := validPrepareJustification(
+				config,
+				prepareMsgs,
+				height,
+				rcmData.PreparedRound,
+				rcmData.PreparedValue,
+				state.Share.Committee,
+			); err != nil {
+				return errors.New("signed prepare not valid")
 			}
 			return nil
 		}
@@ -210,12 +212,21 @@ func proposer(state *State, config IConfig, round Round) types.OperatorID {
                         extractSignedPrepares(prepares));
 */
 func CreateProposal(state *State, config IConfig, value []byte, roundChanges, prepares []*SignedMessage) (*SignedMessage, error) {
+	roundChanges, err := dedupeJustification(roundChanges)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not dedupe round change justification")
+	}
+	prepares, err = compactPrepareJustification(prepares)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compact prepare justification")
+	}
+
 	proposalData := &ProposalData{
 		Data:                     value,
 		RoundChangeJustification: roundChanges,
 		PrepareJustification:     prepares,
 	}
-	dataByts, err := proposalData.Encode()
+	dataByts, err := proposalData.EncodeWithVersion(config.GetEncodingVersion())
 	if err != nil {
 		return nil, errors.Wrap(err, "could not encode proposal data")
 	}
@@ -226,7 +237,7 @@ func CreateProposal(state *State, config IConfig, value []byte, roundChanges, pr
 		Identifier: state.ID,
 		Data:       dataByts,
 	}
-	sig, err := config.GetSigner().SignRoot(msg, types.QBFTSignatureType, state.Share.SharePubKey)
+	sig, err := config.GetSigner().SignRoot(msg.withVersion(config.GetEncodingVersion()), types.QBFTSignatureType, state.Share.SharePubKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed signing prepare msg")
 	}
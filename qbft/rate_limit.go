@@ -0,0 +1,154 @@
+package qbft
+
+import (
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+// RateLimit limits the msgs a controller processes per signer (a controller processes a single identifier's msgs).
+// Msgs above the limits are rejected before their (BLS) signature is verified, decided and multi signer msgs are not limited
+type RateLimit struct {
+	// MaxRoundsAhead is the max number of rounds a msg can be ahead of its instance's round (the first round for instances not started yet), 0 for no limit.
+	// Without a limit, msgs more than maxCountedRoundsAhead rounds ahead share a single count for MaxMsgsPerRound
+	MaxRoundsAhead Round
+	// MaxMsgsPerRound is the max number of msgs of every msg type a signer can send for a height and round, 0 for no limit.
+	// Only msgs with a valid signature are counted so msgs forged in an operator's name don't exhaust its limit
+	MaxMsgsPerRound int
+}
+
+// maxCountedRoundsAhead is the number of rounds ahead of an instance's round counted separately if RateLimit.MaxRoundsAhead is not set, bounding the counted rounds
+const maxCountedRoundsAhead Round = 8
+
+type rateLimitKey struct {
+	signer  types.OperatorID
+	height  Height
+	round   Round
+	msgType MessageType
+}
+
+// rateLimiter counts the validly signed msgs of every signer per height, round and msg type
+type rateLimiter struct {
+	counts map[rateLimitKey]int
+	// minHeight is the lowest height counted, lower heights were pruned
+	minHeight Height
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		counts: make(map[rateLimitKey]int),
+	}
+}
+
+// prune removes the counts of heights below minHeight
+func (r *rateLimiter) prune(minHeight Height) {
+	if minHeight <= r.minHeight {
+		return
+	}
+	r.minHeight = minHeight
+	for key := range r.counts {
+		if key.height < minHeight {
+			delete(r.counts, key)
+		}
+	}
+}
+
+// screenMsg rejects (and reports as misbehavior) malformed msgs, msgs above the config's RateLimit and msgs with an invalid signature, in that order so rate limited msgs are never verified.
+// Verified msgs are marked as such and not verified again by their instance.
+// Does nothing if neither a RateLimit nor a MisbehaviorF is configured, leaving all validation to msg processing
+func (c *Controller) screenMsg(msg *SignedMessage) error {
+	key, limited, err := c.screenMsgLimits(msg, nil)
+	if err != nil {
+		return err
+	}
+	if !c.screening() {
+		return nil
+	}
+
+	if err := verifyMsgSignature(c.GetConfig(), msg, c.Share.Committee); err != nil {
+		err = errors.Wrap(err, "msg signature invalid")
+		c.reportMisbehavior(InvalidSignatureMisbehavior, msg, err)
+		return err
+	}
+	msg.markSignatureVerified(c.GetConfig(), c.Share.Committee)
+
+	if limited {
+		c.rateLimiter.counts[key]++
+	}
+	return nil
+}
+
+// screening returns true if msgs are screened, a RateLimit or a MisbehaviorF is configured
+func (c *Controller) screening() bool {
+	return c.GetConfig().GetRateLimit() != nil || c.GetConfig().GetMisbehaviorF() != nil
+}
+
+// screenMsgLimits rejects (and reports as misbehavior) malformed msgs and msgs above the config's RateLimit without verifying their signature.
+// Returns the msg's rate limit key and true if the msg is counted by the RateLimit.
+// batchCounts (optional) counts the msgs of a batch screened ahead of processing, so a batch can't exceed the limits before its msgs are counted
+func (c *Controller) screenMsgLimits(msg *SignedMessage, batchCounts map[rateLimitKey]int) (rateLimitKey, bool, error) {
+	var key rateLimitKey
+	if !c.screening() {
+		return key, false, nil
+	}
+
+	if err := msg.Validate(); err != nil {
+		err = errors.Wrap(err, "invalid signed message")
+		c.reportMisbehavior(InvalidMsgMisbehavior, msg, err)
+		return key, false, err
+	}
+
+	limit := c.GetConfig().GetRateLimit()
+	if limit == nil || len(msg.Signers) != 1 || isDecidedMsg(c.Share, msg) {
+		return key, false, nil
+	}
+
+	if c.rateLimiter == nil {
+		c.rateLimiter = newRateLimiter()
+	}
+	c.rateLimiter.prune(c.Height - Height(c.StoredInstances.Capacity()) + 1)
+
+	if err := c.checkRateLimit(limit, msg); err != nil {
+		c.reportMisbehavior(RateLimitedMisbehavior, msg, err)
+		return key, false, err
+	}
+
+	// future heights (and rounds far ahead if not limited) share a single count to bound the counted heights and rounds
+	height := msg.Message.Height
+	if height > c.Height+1 {
+		height = c.Height + 1
+	}
+	round := msg.Message.Round
+	if maxRound := c.instanceRound(msg.Message.Height) + maxCountedRoundsAhead; limit.MaxRoundsAhead <= 0 && round > maxRound {
+		round = maxRound
+	}
+	key = rateLimitKey{signer: msg.Signers[0], height: height, round: round, msgType: msg.Message.MsgType}
+	if limit.MaxMsgsPerRound > 0 && c.rateLimiter.counts[key]+batchCounts[key] >= limit.MaxMsgsPerRound {
+		err := errors.New("msg rate limited: too many msgs for round")
+		c.reportMisbehavior(RateLimitedMisbehavior, msg, err)
+		return key, false, err
+	}
+	if batchCounts != nil {
+		batchCounts[key]++
+	}
+	return key, true, nil
+}
+
+// checkRateLimit returns an error if msg's round is too far ahead of its instance's round
+func (c *Controller) checkRateLimit(limit *RateLimit, msg *SignedMessage) error {
+	if limit.MaxRoundsAhead <= 0 {
+		return nil
+	}
+
+	if msg.Message.Round > c.instanceRound(msg.Message.Height)+limit.MaxRoundsAhead {
+		return errors.New("msg rate limited: round too far ahead")
+	}
+	return nil
+}
+
+// instanceRound returns the round of the instance for height, FirstRound if not started
+func (c *Controller) instanceRound(height Height) Round {
+	if inst := c.InstanceForHeight(height); inst != nil {
+		return inst.State.Round
+	}
+	return FirstRound
+}
@@ -0,0 +1,124 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestController_RateLimit(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	setup := func(limit *qbft.RateLimit) (*qbft.Controller, *[]*qbft.Misbehavior) {
+		misbehaviors := make([]*qbft.Misbehavior, 0)
+		config := testingutils.TestingConfig(ks)
+		config.RateLimit = limit
+		config.MisbehaviorF = func(misbehavior *qbft.Misbehavior) {
+			misbehaviors = append(misbehaviors, misbehavior)
+		}
+		c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		return c, &misbehaviors
+	}
+	rc := func(id types.OperatorID, height qbft.Height, round qbft.Round) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+			MsgType:    qbft.RoundChangeMsgType,
+			Height:     height,
+			Round:      round,
+			Identifier: identifier[:],
+			Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+		})
+	}
+
+	t.Run("rounds ahead", func(t *testing.T) {
+		c, misbehaviors := setup(&qbft.RateLimit{MaxRoundsAhead: 2})
+		_, err := c.ProcessMsg(rc(2, qbft.FirstHeight, 4))
+		require.EqualError(t, err, "invalid msg: msg rate limited: round too far ahead")
+		require.Len(t, *misbehaviors, 1)
+		require.EqualValues(t, qbft.RateLimitedMisbehavior, (*misbehaviors)[0].Type)
+		require.EqualValues(t, []types.OperatorID{2}, (*misbehaviors)[0].Signers)
+		require.EqualValues(t, 4, (*misbehaviors)[0].Round)
+		require.False(t, (*misbehaviors)[0].Attributed) // rejected before verifying the signature
+
+		_, err = c.ProcessMsg(rc(2, qbft.FirstHeight, 3))
+		require.NoError(t, err)
+		require.Len(t, *misbehaviors, 1)
+	})
+
+	t.Run("msgs per round", func(t *testing.T) {
+		c, misbehaviors := setup(&qbft.RateLimit{MaxMsgsPerRound: 1})
+		_, err := c.ProcessMsg(rc(2, qbft.FirstHeight, 2))
+		require.NoError(t, err)
+		_, err = c.ProcessMsg(rc(2, qbft.FirstHeight, 2))
+		require.EqualError(t, err, "invalid msg: msg rate limited: too many msgs for round")
+		require.Len(t, *misbehaviors, 1)
+		require.EqualValues(t, qbft.RateLimitedMisbehavior, (*misbehaviors)[0].Type)
+
+		// other signers, rounds and msg types have their own limits
+		_, err = c.ProcessMsg(rc(3, qbft.FirstHeight, 2))
+		require.NoError(t, err)
+		_, err = c.ProcessMsg(rc(2, qbft.FirstHeight, 3))
+		require.NoError(t, err)
+		require.Len(t, *misbehaviors, 1)
+	})
+
+	t.Run("forged msgs not counted", func(t *testing.T) {
+		c, misbehaviors := setup(&qbft.RateLimit{MaxMsgsPerRound: 1})
+		forged := rc(2, qbft.FirstHeight, 2)
+		forged.Signature = rc(3, qbft.FirstHeight, 2).Signature
+		_, err := c.ProcessMsg(forged)
+		require.EqualError(t, err, "invalid msg: msg signature invalid: failed to verify signature")
+		require.Len(t, *misbehaviors, 1)
+		require.EqualValues(t, qbft.InvalidSignatureMisbehavior, (*misbehaviors)[0].Type)
+
+		_, err = c.ProcessMsg(rc(2, qbft.FirstHeight, 2))
+		require.NoError(t, err)
+	})
+
+	t.Run("future heights share a limit", func(t *testing.T) {
+		c, _ := setup(&qbft.RateLimit{MaxMsgsPerRound: 1})
+		_, err := c.ProcessMsg(rc(2, 5, qbft.FirstRound))
+		require.NoError(t, err)
+		_, err = c.ProcessMsg(rc(2, 6, qbft.FirstRound))
+		require.EqualError(t, err, "invalid msg: msg rate limited: too many msgs for round")
+	})
+
+	t.Run("far rounds share a limit", func(t *testing.T) {
+		c, _ := setup(&qbft.RateLimit{MaxMsgsPerRound: 1})
+		_, err := c.ProcessMsg(rc(2, qbft.FirstHeight, 20))
+		require.NoError(t, err)
+		_, err = c.ProcessMsg(rc(2, qbft.FirstHeight, 30))
+		require.EqualError(t, err, "invalid msg: msg rate limited: too many msgs for round")
+	})
+
+	t.Run("decided not limited", func(t *testing.T) {
+		c, misbehaviors := setup(&qbft.RateLimit{MaxRoundsAhead: 1, MaxMsgsPerRound: 1})
+		decided, err := c.ProcessMsg(testingutils.MultiSignQBFTMsg(
+			[]*bls.SecretKey{ks.Shares[1], ks.Shares[2], ks.Shares[3]},
+			[]types.OperatorID{1, 2, 3},
+			&qbft.Message{
+				MsgType:    qbft.CommitMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      10,
+				Identifier: identifier[:],
+				Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+			}))
+		require.NoError(t, err)
+		require.NotNil(t, decided)
+		require.Len(t, *misbehaviors, 0)
+	})
+
+	t.Run("invalid msg", func(t *testing.T) {
+		c, misbehaviors := setup(nil)
+		msg := rc(2, qbft.FirstHeight, 2)
+		msg.Signers = []types.OperatorID{}
+		_, err := c.ProcessMsg(msg)
+		require.EqualError(t, err, "invalid msg: invalid signed message: message signers is empty")
+		require.Len(t, *misbehaviors, 1)
+		require.EqualValues(t, qbft.InvalidMsgMisbehavior, (*misbehaviors)[0].Type)
+		require.EqualValues(t, identifier[:], (*misbehaviors)[0].Identifier)
+	})
+}
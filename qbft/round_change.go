@@ -3,6 +3,7 @@ package qbft
 import (
 	"github.com/bloxapp/ssv-spec/types"
 	"github.com/pkg/errors"
+	"sort"
 )
 
 func (i *Instance) uponRoundChange(
@@ -33,51 +34,120 @@ func (i *Instance) uponRoundChange(
 		return errors.Wrap(err, "could not get proposal justification for leading ronud")
 	}
 	if justifiedRoundChangeMsg != nil {
-		highestRCData, err := justifiedRoundChangeMsg.Message.GetRoundChangeData()
-		if err != nil {
-			return errors.Wrap(err, "could not round change data from highestJustifiedRoundChangeMsg")
+		if err := i.proposeForJustifiedRoundChange(instanceStartValue, justifiedRoundChangeMsg, roundChangeMsgContainer); err != nil {
+			return err
 		}
-
-		// Chose proposal value.
-		// If justifiedRoundChangeMsg has no prepare justification chose state value
-		// If justifiedRoundChangeMsg has prepare justification chose prepared value
-		valueToPropose := instanceStartValue
-		if highestRCData.Prepared() {
-			valueToPropose = highestRCData.PreparedValue
+	} else if partialQuorum, rcs := hasReceivedPartialQuorum(i.State, roundChangeMsgContainer); partialQuorum {
+		newRound := minRound(rcs)
+		if newRound <= i.State.Round {
+			return nil // no need to advance round
 		}
 
-		proposal, err := CreateProposal(
-			i.State,
-			i.config,
-			valueToPropose,
-			roundChangeMsgContainer.MessagesForRound(i.State.Round), // TODO - might be optimized to include only necessary quorum
-			highestRCData.RoundChangeJustification,
-		)
+		err := i.uponChangeRoundPartialQuorum(signedRoundChange, newRound, instanceStartValue)
 		if err != nil {
-			return errors.Wrap(err, "failed to create proposal")
+			return err
 		}
+	}
+	return nil
+}
 
-		if err := i.Broadcast(proposal); err != nil {
-			return errors.Wrap(err, "failed to broadcast proposal message")
+// uponSyncedRoundChanges adds all valid synced round change msgs to the container before acting upon them once:
+// - jumps to the highest round for which f+1 signers sent a round change msg of that round or higher
+// - proposes if leader for the (new) round with a justified round change quorum
+// Invalid msgs are skipped, returns the last invalid msg error if no other error occurred
+func (i *Instance) uponSyncedRoundChanges(
+	instanceStartValue []byte,
+	signedRoundChanges []*SignedMessage,
+	roundChangeMsgContainer *MsgContainer,
+	valCheck ProposedValueCheckF,
+) error {
+	var lastErr error
+	for _, signedRoundChange := range signedRoundChanges {
+		if err := signedRoundChange.Validate(); err != nil {
+			lastErr = errors.Wrap(err, "invalid synced round change msg")
+			continue
 		}
-	} else if partialQuorum, rcs := hasReceivedPartialQuorum(i.State, roundChangeMsgContainer); partialQuorum {
-		newRound := minRound(rcs)
-		if newRound <= i.State.Round {
-			return nil // no need to advance round
+		i.onMsgEquivocation(signedRoundChange)
+		if err := validRoundChange(i.State, i.config, signedRoundChange, i.State.Height, signedRoundChange.Message.Round); err != nil {
+			lastErr = errors.Wrap(err, "invalid synced round change msg")
+			i.emit(MessageRejectedEvent, signedRoundChange, nil, lastErr)
+			continue
 		}
+		if _, err := roundChangeMsgContainer.AddFirstMsgForSignerAndRound(signedRoundChange); err != nil {
+			return errors.Wrap(err, "could not add round change msg to container")
+		}
+	}
 
-		err := i.uponChangeRoundPartialQuorum(newRound, instanceStartValue)
-		if err != nil {
+	if newRound, signedRoundChange := highestPartialQuorumRound(i.State, roundChangeMsgContainer); newRound > i.State.Round {
+		if err := i.uponChangeRoundPartialQuorum(signedRoundChange, newRound, instanceStartValue); err != nil {
+			return err
+		}
+	}
+
+	roundChanges := roundChangeMsgContainer.MessagesForRound(i.State.Round)
+	if len(roundChanges) == 0 {
+		return lastErr
+	}
+	justifiedRoundChangeMsg, err := hasReceivedProposalJustificationForLeadingRound(
+		i.State,
+		i.config,
+		roundChanges[0],
+		roundChangeMsgContainer,
+		valCheck)
+	if err != nil {
+		return errors.Wrap(err, "could not get proposal justification for leading ronud")
+	}
+	if justifiedRoundChangeMsg != nil {
+		if err := i.proposeForJustifiedRoundChange(instanceStartValue, justifiedRoundChangeMsg, roundChangeMsgContainer); err != nil {
 			return err
 		}
 	}
+	return lastErr
+}
+
+// proposeForJustifiedRoundChange creates and broadcasts a proposal justified by justifiedRoundChangeMsg and the round change msgs of the current round
+func (i *Instance) proposeForJustifiedRoundChange(
+	instanceStartValue []byte,
+	justifiedRoundChangeMsg *SignedMessage,
+	roundChangeMsgContainer *MsgContainer,
+) error {
+	highestRCData, err := justifiedRoundChangeMsg.Message.GetRoundChangeData()
+	if err != nil {
+		return errors.Wrap(err, "could not round change data from highestJustifiedRoundChangeMsg")
+	}
+
+	// Chose proposal value.
+	// If justifiedRoundChangeMsg has no prepare justification chose state value (or a fresh value from the config's ValueProvider)
+	// If justifiedRoundChangeMsg has prepare justification chose prepared value
+	var valueToPropose []byte
+	if highestRCData.Prepared() {
+		valueToPropose = highestRCData.PreparedValue
+	} else {
+		valueToPropose = i.valueForRound(instanceStartValue)
+	}
+
+	proposal, err := CreateProposal(
+		i.State,
+		i.config,
+		valueToPropose,
+		roundChangeMsgContainer.MessagesForRound(i.State.Round), // TODO - might be optimized to include only necessary quorum
+		highestRCData.RoundChangeJustification,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create proposal")
+	}
+
+	if err := i.Broadcast(proposal); err != nil {
+		return errors.Wrap(err, "failed to broadcast proposal message")
+	}
 	return nil
 }
 
-func (i *Instance) uponChangeRoundPartialQuorum(newRound Round, instanceStartValue []byte) error {
+func (i *Instance) uponChangeRoundPartialQuorum(signedRoundChange *SignedMessage, newRound Round, instanceStartValue []byte) error {
 	i.State.Round = newRound
 	i.State.ProposalAcceptedForCurrentRound = nil
-	i.config.GetTimer().TimeoutForRound(i.State.Round)
+	i.timeoutForRound(i.State.Round)
+	i.emit(RoundChangedEvent, signedRoundChange, nil, nil)
 	roundChange, err := CreateRoundChange(i.State, i.config, newRound, instanceStartValue)
 	if err != nil {
 		return errors.Wrap(err, "failed to create round change message")
@@ -101,6 +171,38 @@ func hasReceivedPartialQuorum(state *State, roundChangeMsgContainer *MsgContaine
 	return HasPartialQuorum(state.Share, rc), rc
 }
 
+// highestPartialQuorumRound returns the highest round (> state round) for which f+1 signers sent a round change msg of that round or higher and a round change msg of that round.
+// Returns NoRound if no such round found
+func highestPartialQuorumRound(state *State, roundChangeMsgContainer *MsgContainer) (Round, *SignedMessage) {
+	// highest round change msg per signer
+	highest := make(map[types.OperatorID]*SignedMessage)
+	for _, msg := range roundChangeMsgContainer.AllMessaged() {
+		if msg.Message.Round <= state.Round {
+			continue
+		}
+		for _, signer := range msg.GetSigners() {
+			if prev, found := highest[signer]; !found || prev.Message.Round < msg.Message.Round {
+				highest[signer] = msg
+			}
+		}
+	}
+
+	msgs := make([]*SignedMessage, 0, len(highest))
+	for _, msg := range highest {
+		msgs = append(msgs, msg)
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].Message.Round > msgs[j].Message.Round
+	})
+
+	for idx, msg := range msgs {
+		if state.Share.HasPartialQuorum(idx + 1) {
+			return msg.Message.Round, msg
+		}
+	}
+	return NoRound, nil
+}
+
 // hasReceivedProposalJustificationForLeadingRound returns
 // if first round or not received round change msgs with prepare justification - returns first rc msg in container
 // if received round change msgs with prepare justification - returns the highest prepare justification round change msg
@@ -214,7 +316,7 @@ func validRoundChange(state *State, config IConfig, signedMsg *SignedMessage, he
 		return errors.New("round change msg allows 1 signer")
 	}
 
-	if err := signedMsg.Signature.VerifyByOperators(signedMsg, config.GetSignatureDomainType(), types.QBFTSignatureType, state.Share.Committee); err != nil {
+	if err := verifyMsgSignature(config, signedMsg, state.Share.Committee); err != nil {
 		return errors.Wrap(err, "round change msg signature invalid")
 	}
 
@@ -223,7 +325,7 @@ func validRoundChange(state *State, config IConfig, signedMsg *SignedMessage, he
 		return errors.Wrap(err, "could not get roundChange data ")
 	}
 	if err := rcData.Validate(); err != nil {
-		return errors.Wrap(err, "roundChangeData invalid")
+		return provenMisbehavior(errors.Wrap(err, "roundChangeData invalid"))
 	}
 
 	// Addition to formal spec
@@ -231,24 +333,22 @@ func validRoundChange(state *State, config IConfig, signedMsg *SignedMessage, he
 	if rcData.Prepared() {
 		// validate prepare message justifications
 		prepareMsgs := rcData.RoundChangeJustification
-		for _, pm := range prepareMsgs {
-			if err := validSignedPrepareForHeightRoundAndValue(
-				config,
-				pm,
-				state.Height,
-				rcData.PreparedRound,
-				rcData.PreparedValue,
-				state.Share.Committee); err != nil {
-				return errors.Wrap(err, "round change justification invalid")
-			}
+		if err := validPrepareJustification(
+			config,
+			prepareMsgs,
+			state.Height,
+			rcData.PreparedRound,
+			rcData.PreparedValue,
+			state.Share.Committee); err != nil {
+			return provenMisbehavior(errors.Wrap(err, "round change justification invalid"))
 		}
 
 		if !HasQuorum(state.Share, prepareMsgs) {
-			return errors.New("no justifications quorum")
+			return provenMisbehavior(errors.New("no justifications quorum"))
 		}
 
 		if rcData.PreparedRound > round {
-			return errors.New("prepared round > round")
+			return provenMisbehavior(errors.New("prepared round > round"))
 		}
 
 		return nil
@@ -297,7 +397,10 @@ func minRound(roundChangeMsgs []*SignedMessage) Round {
 
 func getRoundChangeData(state *State, config IConfig, instanceStartValue []byte) (*RoundChangeData, error) {
 	if state.LastPreparedRound != NoRound && state.LastPreparedValue != nil {
-		justifications := getRoundChangeJustification(state, config, state.PrepareContainer)
+		justifications, err := getRoundChangeJustification(state, config, state.PrepareContainer)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get round change justification")
+		}
 		return &RoundChangeData{
 			PreparedRound:            state.LastPreparedRound,
 			PreparedValue:            state.LastPreparedValue,
@@ -328,7 +431,7 @@ func CreateRoundChange(state *State, config IConfig, newRound Round, instanceSta
 	if err != nil {
 		return nil, errors.Wrap(err, "could not generate round change data")
 	}
-	dataByts, err := rcData.Encode()
+	dataByts, err := rcData.EncodeWithVersion(config.GetEncodingVersion())
 	if err != nil {
 		return nil, errors.Wrap(err, "could not encode round change data")
 	}
@@ -340,7 +443,7 @@ func CreateRoundChange(state *State, config IConfig, newRound Round, instanceSta
 		Identifier: state.ID,
 		Data:       dataByts,
 	}
-	sig, err := config.GetSigner().SignRoot(msg, types.QBFTSignatureType, state.Share.SharePubKey)
+	sig, err := config.GetSigner().SignRoot(msg.withVersion(config.GetEncodingVersion()), types.QBFTSignatureType, state.Share.SharePubKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed signing prepare msg")
 	}
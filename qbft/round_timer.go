@@ -0,0 +1,111 @@
+package qbft
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock abstracts scheduling so RoundTimer can be driven by a fake clock in tests
+type Clock interface {
+	// AfterFunc calls f (in its own goroutine) after d elapsed, returns a func that cancels the call.
+	// The returned func returns false if the call already fired or was canceled
+	AfterFunc(d time.Duration, f func()) func() bool
+}
+
+type systemClock struct{}
+
+// AfterFunc is an interface implementation using time.AfterFunc
+func (c systemClock) AfterFunc(d time.Duration, f func()) func() bool {
+	return time.AfterFunc(d, f).Stop
+}
+
+// RoundTimer is a real-time Timer implementation.
+// Every TimeoutForRound call resets the running timer, once a timer fires onTimeout is called with the timed out round.
+// onTimeout is called from the timer's goroutine and is expected to serialize into the controller, for example (with an InstanceTimerF):
+//
+//	config.InstanceTimerF = func(identifier []byte, height Height) Timer {
+//		return NewRoundTimer(ctx, func(round Round) {
+//			controller.UponRoundTimeout(height, round)
+//		})
+//	}
+type RoundTimer struct {
+	ctx       context.Context
+	clock     Clock
+	onTimeout func(round Round)
+
+	lock  sync.Mutex
+	round Round
+	// generation is bumped on every reset so a timer that fired concurrently with a reset is ignored
+	generation uint64
+	stop       func() bool
+}
+
+// NewRoundTimer returns a RoundTimer driven by the system clock, no timeouts will fire once ctx is done
+func NewRoundTimer(ctx context.Context, onTimeout func(round Round)) *RoundTimer {
+	return NewRoundTimerWithClock(ctx, systemClock{}, onTimeout)
+}
+
+// NewRoundTimerWithClock returns a RoundTimer driven by the provided clock, no timeouts will fire once ctx is done
+func NewRoundTimerWithClock(ctx context.Context, clock Clock, onTimeout func(round Round)) *RoundTimer {
+	return &RoundTimer{
+		ctx:       ctx,
+		clock:     clock,
+		onTimeout: onTimeout,
+	}
+}
+
+// TimeoutForRound will reset running timer if exists and will start a new timer for a specific round, firing after timeout
+func (t *RoundTimer) TimeoutForRound(round Round, timeout time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.resetUnsafe()
+	if t.ctx.Err() != nil {
+		return
+	}
+
+	t.round = round
+	generation := t.generation
+	t.stop = t.clock.AfterFunc(timeout, func() {
+		t.fire(generation)
+	})
+}
+
+// Stop stops the running timer if exists
+func (t *RoundTimer) Stop() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.resetUnsafe()
+}
+
+// Round returns the round the timer was last set for
+func (t *RoundTimer) Round() Round {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.round
+}
+
+func (t *RoundTimer) resetUnsafe() {
+	t.generation++
+	if t.stop != nil {
+		t.stop()
+		t.stop = nil
+	}
+}
+
+func (t *RoundTimer) fire(generation uint64) {
+	t.lock.Lock()
+	if generation != t.generation || t.ctx.Err() != nil {
+		t.lock.Unlock()
+		return
+	}
+	round := t.round
+	t.stop = nil
+	t.lock.Unlock()
+
+	// called without holding the lock as onTimeout is expected to reset the timer for the next round
+	t.onTimeout(round)
+}
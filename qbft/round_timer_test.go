@@ -0,0 +1,193 @@
+package qbft_test
+
+import (
+	"context"
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRoundTimer_TimeoutForRound(t *testing.T) {
+	t.Run("fires after timeout", func(t *testing.T) {
+		clock := testingutils.NewTestingClock()
+		fired := make([]qbft.Round, 0)
+		timer := qbft.NewRoundTimerWithClock(context.Background(), clock, func(round qbft.Round) {
+			fired = append(fired, round)
+		})
+
+		timer.TimeoutForRound(1, 2*time.Second)
+		clock.Advance(time.Second)
+		require.Len(t, fired, 0)
+		clock.Advance(time.Second)
+		require.EqualValues(t, []qbft.Round{1}, fired)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		clock := testingutils.NewTestingClock()
+		fired := make([]qbft.Round, 0)
+		timer := qbft.NewRoundTimerWithClock(context.Background(), clock, func(round qbft.Round) {
+			fired = append(fired, round)
+		})
+
+		timer.TimeoutForRound(1, 2*time.Second)
+		clock.Advance(time.Second)
+		timer.TimeoutForRound(2, 4*time.Second)
+		clock.Advance(3 * time.Second)
+		require.Len(t, fired, 0)
+		clock.Advance(time.Second)
+		require.EqualValues(t, []qbft.Round{2}, fired)
+		require.EqualValues(t, 2, timer.Round())
+		require.EqualValues(t, 0, clock.Pending())
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		clock := testingutils.NewTestingClock()
+		fired := make([]qbft.Round, 0)
+		timer := qbft.NewRoundTimerWithClock(context.Background(), clock, func(round qbft.Round) {
+			fired = append(fired, round)
+		})
+
+		timer.TimeoutForRound(1, 2*time.Second)
+		timer.Stop()
+		clock.Advance(time.Minute)
+		require.Len(t, fired, 0)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		clock := testingutils.NewTestingClock()
+		fired := make([]qbft.Round, 0)
+		ctx, cancel := context.WithCancel(context.Background())
+		timer := qbft.NewRoundTimerWithClock(ctx, clock, func(round qbft.Round) {
+			fired = append(fired, round)
+		})
+
+		timer.TimeoutForRound(1, 2*time.Second)
+		cancel()
+		clock.Advance(time.Minute)
+		timer.TimeoutForRound(2, 2*time.Second)
+		clock.Advance(time.Minute)
+		require.Len(t, fired, 0)
+	})
+
+	t.Run("system clock", func(t *testing.T) {
+		fired := make(chan qbft.Round, 1)
+		timer := qbft.NewRoundTimer(context.Background(), func(round qbft.Round) {
+			fired <- round
+		})
+
+		timer.TimeoutForRound(3, time.Millisecond)
+		select {
+		case round := <-fired:
+			require.EqualValues(t, 3, round)
+		case <-time.After(time.Second):
+			t.Fatal("timer didn't fire")
+		}
+	})
+}
+
+func TestRoundTimer_DrivesInstance(t *testing.T) {
+	clock := testingutils.NewTestingClock()
+	inst := testingutils.BaseInstance()
+	config := inst.GetConfig().(*qbft.Config)
+	config.Timer = qbft.NewRoundTimerWithClock(context.Background(), clock, func(round qbft.Round) {
+		require.NoError(t, inst.ProcessTimeout(round))
+	})
+
+	inst.Start([]byte{1, 2, 3, 4}, qbft.FirstHeight)
+	require.EqualValues(t, qbft.FirstRound, inst.State.Round)
+
+	// round 1 times out after 2^1 seconds
+	clock.Advance(2 * time.Second)
+	require.EqualValues(t, 2, inst.State.Round)
+
+	// round 2 times out after 2^2 seconds
+	clock.Advance(3 * time.Second)
+	require.EqualValues(t, 2, inst.State.Round)
+	clock.Advance(time.Second)
+	require.EqualValues(t, 3, inst.State.Round)
+
+	// proposal + 2 round changes
+	require.Len(t, config.GetNetwork().(*testingutils.TestingNetwork).BroadcastedMsgs, 3)
+
+	// stale timeouts are ignored
+	require.NoError(t, inst.ProcessTimeout(2))
+	require.EqualValues(t, 3, inst.State.Round)
+}
+
+func TestRoundTimer_DrivesController(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	clock := testingutils.NewTestingClock()
+	config := testingutils.TestingConfig(ks)
+	config.ControllerStorage = testingutils.NewTestingStorage()
+
+	var c *qbft.Controller
+	config.InstanceTimerF = func(identifier []byte, height qbft.Height) qbft.Timer {
+		return qbft.NewRoundTimerWithClock(context.Background(), clock, func(round qbft.Round) {
+			// nolint
+			c.UponRoundTimeout(height, round)
+		})
+	}
+	c = testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+	require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+	net := config.GetNetwork().(*testingutils.TestingNetwork)
+	require.Len(t, net.BroadcastedMsgs, 1) // proposal
+
+	checkpointedRound := func() qbft.Round {
+		byts, err := config.ControllerStorage.GetControllerSnapshot(identifier[:])
+		require.NoError(t, err)
+		restored := &qbft.Controller{}
+		require.NoError(t, restored.Decode(byts))
+		return restored.InstanceForHeight(qbft.FirstHeight).State.Round
+	}
+	require.EqualValues(t, qbft.FirstRound, checkpointedRound())
+
+	// round 1 times out after 2^1 seconds
+	clock.Advance(time.Second)
+	require.EqualValues(t, qbft.FirstRound, c.InstanceForHeight(qbft.FirstHeight).State.Round)
+	clock.Advance(time.Second)
+	require.EqualValues(t, 2, c.InstanceForHeight(qbft.FirstHeight).State.Round)
+	require.EqualValues(t, 2, checkpointedRound())
+
+	require.Len(t, net.BroadcastedMsgs, 2)
+	broadcasted := &qbft.SignedMessage{}
+	require.NoError(t, broadcasted.Decode(net.BroadcastedMsgs[1].Data))
+	require.EqualValues(t, qbft.RoundChangeMsgType, broadcasted.Message.MsgType)
+	require.EqualValues(t, 2, broadcasted.Message.Round)
+	require.EqualValues(t, []types.OperatorID{1}, broadcasted.Signers)
+
+	// round 2 times out after 2^2 seconds
+	clock.Advance(4 * time.Second)
+	require.EqualValues(t, 3, c.InstanceForHeight(qbft.FirstHeight).State.Round)
+	require.EqualValues(t, 3, checkpointedRound())
+	require.Len(t, net.BroadcastedMsgs, 3)
+
+	t.Run("concurrent with msg processing", func(t *testing.T) {
+		// timeouts fire on another goroutine while msgs are processed, checkpointing the controller concurrently (see go test -race)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 5; i++ {
+				clock.Advance(time.Minute)
+			}
+		}()
+		for round := qbft.Round(3); round <= 20; round++ {
+			// nolint
+			c.ProcessMsg(testingutils.SignQBFTMsg(ks.Shares[2], 2, &qbft.Message{
+				MsgType:    qbft.RoundChangeMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      round,
+				Identifier: identifier[:],
+				Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+			}))
+		}
+		<-done
+
+		// every timeout bumped the round
+		require.EqualValues(t, 8, c.InstanceForHeight(qbft.FirstHeight).State.Round)
+		require.EqualValues(t, 8, checkpointedRound())
+	})
+}
@@ -0,0 +1,116 @@
+package simulator
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+// Behavior is an operator's behavior in a simulation, all operators run the same (honest) controller and differ only by the msgs they send
+type Behavior int
+
+const (
+	// Honest operators send their msgs as is
+	Honest Behavior = iota
+	// Silent operators never send msgs nor respond to sync requests
+	Silent
+	// Equivocating operators send every proposal, prepare and commit msg to half of the committee and a conflicting msg (for ByzantineValue) to the other half
+	Equivocating
+	// Delayed operators' msgs are delivered after an extra Config.DelayedLatency
+	Delayed
+	// WrongProposer operators send a proposal (for ByzantineValue) for every round they send msgs for, whether they are the round's proposer or not
+	WrongProposer
+)
+
+// ByzantineValue is the (valid) value proposed by Equivocating and WrongProposer operators
+var ByzantineValue = []byte{0xff, 0xff, 0xff, 0xff}
+
+// String returns the behavior's name
+func (b Behavior) String() string {
+	switch b {
+	case Honest:
+		return "honest"
+	case Silent:
+		return "silent"
+	case Equivocating:
+		return "equivocating"
+	case Delayed:
+		return "delayed"
+	case WrongProposer:
+		return "wrong proposer"
+	default:
+		return "unknown"
+	}
+}
+
+// conflictingMsg returns msg signed by n for ByzantineValue, nil if msg is not a single signer proposal, prepare or commit msg
+func (n *node) conflictingMsg(msg *qbft.SignedMessage) (*qbft.SignedMessage, error) {
+	if len(msg.Signers) != 1 {
+		return nil, nil
+	}
+
+	var data []byte
+	var err error
+	switch msg.Message.MsgType {
+	case qbft.ProposalMsgType:
+		proposalData, err := msg.Message.GetProposalData()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get proposal data")
+		}
+		data, err = (&qbft.ProposalData{
+			Data:                     ByzantineValue,
+			RoundChangeJustification: proposalData.RoundChangeJustification,
+			PrepareJustification:     proposalData.PrepareJustification,
+		}).Encode()
+	case qbft.PrepareMsgType:
+		data, err = (&qbft.PrepareData{Data: ByzantineValue}).Encode()
+	case qbft.CommitMsgType:
+		data, err = (&qbft.CommitData{Data: ByzantineValue}).Encode()
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode conflicting msg data")
+	}
+
+	return n.sign(&qbft.Message{
+		MsgType:    msg.Message.MsgType,
+		Height:     msg.Message.Height,
+		Round:      msg.Message.Round,
+		Identifier: msg.Message.Identifier,
+		Data:       data,
+	})
+}
+
+// wrongProposal returns a proposal signed by n for ByzantineValue for the msg's height and round, nil if n already proposed for them
+func (n *node) wrongProposal(msg *qbft.SignedMessage) (*qbft.SignedMessage, error) {
+	key := heightRound{height: msg.Message.Height, round: msg.Message.Round}
+	if n.proposed[key] {
+		return nil, nil
+	}
+	n.proposed[key] = true
+
+	data, err := (&qbft.ProposalData{Data: ByzantineValue}).Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode proposal data")
+	}
+	return n.sign(&qbft.Message{
+		MsgType:    qbft.ProposalMsgType,
+		Height:     msg.Message.Height,
+		Round:      msg.Message.Round,
+		Identifier: msg.Message.Identifier,
+		Data:       data,
+	})
+}
+
+func (n *node) sign(msg *qbft.Message) (*qbft.SignedMessage, error) {
+	sig, err := n.config.GetSigner().SignRoot(msg, types.QBFTSignatureType, n.share.SharePubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign msg")
+	}
+	return &qbft.SignedMessage{
+		Signature: sig,
+		Signers:   []types.OperatorID{n.share.OperatorID},
+		Message:   msg,
+	}, nil
+}
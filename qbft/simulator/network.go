@@ -0,0 +1,161 @@
+package simulator
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"time"
+)
+
+// network is an operator's qbft.Network, delivering msgs to all operators (including itself) through the simulator's clock
+type network struct {
+	sim  *Simulator
+	node *node
+}
+
+// Broadcast is an interface implementation
+func (net *network) Broadcast(message *types.SSVMessage) error {
+	msg := &qbft.SignedMessage{}
+	if err := msg.Decode(message.Data); err != nil {
+		return err
+	}
+	return net.sim.broadcast(net.node, msg)
+}
+
+// SyncHighestDecided is an interface implementation, responding with the highest decided msg stored by any other (not silent) operator
+func (net *network) SyncHighestDecided(identifier types.MessageID) error {
+	net.sim.schedule(net.sim.latency(), func() {
+		var highest *qbft.SignedMessage
+		for _, peer := range net.sim.nodes {
+			if peer == net.node || peer.behavior == Silent {
+				continue
+			}
+			decided, err := peer.config.GetStorage().GetHighestDecided(identifier[:])
+			if err != nil || decided == nil {
+				continue
+			}
+			if highest == nil || decided.Message.Height > highest.Message.Height {
+				highest = decided
+			}
+		}
+		if highest == nil {
+			return
+		}
+
+		byts, err := highest.Encode()
+		if err != nil {
+			return
+		}
+		net.node.onSyncedDecided(byts)
+	})
+	return nil
+}
+
+// SyncHighestRoundChange is an interface implementation, responding with the highest round change msg sent by every operator for height
+func (net *network) SyncHighestRoundChange(identifier types.MessageID, height qbft.Height) error {
+	net.sim.schedule(net.sim.latency(), func() {
+		msgs := make([][]byte, 0)
+		for _, peer := range net.sim.nodes {
+			if byts, found := net.sim.roundChanges[heightSigner{height: height, signer: peer.share.OperatorID}]; found {
+				msgs = append(msgs, byts)
+			}
+		}
+		net.node.onSyncedRoundChanges(msgs)
+	})
+	return nil
+}
+
+// broadcast sends msg from n to all operators according to n's behavior
+func (sim *Simulator) broadcast(n *node, msg *qbft.SignedMessage) error {
+	if n.behavior == Silent {
+		return nil
+	}
+
+	if msg.Message.MsgType == qbft.RoundChangeMsgType {
+		sim.trackRoundChange(msg)
+	}
+
+	var conflicting *qbft.SignedMessage
+	var err error
+	switch n.behavior {
+	case Equivocating:
+		if conflicting, err = n.conflictingMsg(msg); err != nil {
+			return err
+		}
+	case WrongProposer:
+		if msg.Message.MsgType != qbft.ProposalMsgType {
+			proposal, err := n.wrongProposal(msg)
+			if err != nil {
+				return err
+			}
+			if proposal != nil {
+				if err := sim.send(n, proposal, sim.nodes); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if conflicting == nil {
+		return sim.send(n, msg, sim.nodes)
+	}
+
+	// the committee is split in halves by position, deterministic for the simulation
+	first, second := make([]*node, 0), make([]*node, 0)
+	for idx, peer := range sim.nodes {
+		if idx%2 == 0 {
+			first = append(first, peer)
+		} else {
+			second = append(second, peer)
+		}
+	}
+	if err := sim.send(n, msg, first); err != nil {
+		return err
+	}
+	return sim.send(n, conflicting, second)
+}
+
+// send schedules the delivery of msg to every peer with a random latency
+func (sim *Simulator) send(n *node, msg *qbft.SignedMessage, peers []*node) error {
+	byts, err := msg.Encode()
+	if err != nil {
+		return err
+	}
+
+	for _, peer := range peers {
+		peer := peer
+		latency := sim.latency()
+		if n.behavior == Delayed {
+			latency += sim.config.delayedLatency()
+		}
+		sim.schedule(latency, func() {
+			peer.onMsg(byts)
+		})
+	}
+	return nil
+}
+
+// trackRoundChange keeps the highest round change msg per signer and height, for SyncHighestRoundChange responses
+func (sim *Simulator) trackRoundChange(msg *qbft.SignedMessage) {
+	byts, err := msg.Encode()
+	if err != nil {
+		return
+	}
+	key := heightSigner{height: msg.Message.Height, signer: msg.Signers[0]}
+	if prev, found := sim.roundChanges[key]; found {
+		prevMsg := &qbft.SignedMessage{}
+		if err := prevMsg.Decode(prev); err == nil && prevMsg.Message.Round >= msg.Message.Round {
+			return
+		}
+	}
+	sim.roundChanges[key] = byts
+}
+
+// latency returns a random latency up to the config's max latency
+func (sim *Simulator) latency() time.Duration {
+	return time.Duration(sim.rand.Int63n(int64(sim.config.maxLatency()) + 1))
+}
+
+// schedule calls f after d elapsed on the simulator's clock
+func (sim *Simulator) schedule(d time.Duration, f func()) {
+	sim.clock.AfterFunc(d, f)
+}
@@ -0,0 +1,338 @@
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/pkg/errors"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultMaxLatency is the max msg latency if not configured
+	DefaultMaxLatency = 200 * time.Millisecond
+	// DefaultDelayedLatency is the extra latency of Delayed operators' msgs if not configured, longer than the first round's timeout
+	DefaultDelayedLatency = 3 * time.Second
+	// DefaultTimeout is the virtual time for honest operators to decide all heights if not configured
+	DefaultTimeout = 10 * time.Minute
+
+	// step is the virtual time advanced between termination checks
+	step = 100 * time.Millisecond
+	// msgQueueCapacity is the capacity of every operator's msg queue
+	msgQueueCapacity = 4096
+)
+
+// Config configures a simulation
+type Config struct {
+	// KeySet is the committee's key set, e.g. testingutils.Testing4SharesSet()
+	KeySet *testingutils.TestKeySet
+	// Behaviors maps operators to their behavior, operators not found are Honest.
+	// Safety and liveness are guaranteed only for up to f non honest operators
+	Behaviors map[types.OperatorID]Behavior
+	// Heights is the number of consecutive instances every operator runs, 1 if not set
+	Heights int
+	// Seed seeds msg latencies and operators' start times, the same seed always results in the same simulation
+	Seed int64
+	// MaxLatency is the max latency of a delivered msg (uniformly distributed), DefaultMaxLatency if not set
+	MaxLatency time.Duration
+	// DelayedLatency is the extra latency of msgs sent by Delayed operators, DefaultDelayedLatency if not set
+	DelayedLatency time.Duration
+	// Timeout is the virtual time for all honest operators to decide all heights, DefaultTimeout if not set
+	Timeout time.Duration
+}
+
+func (c *Config) heights() int {
+	if c.Heights < 1 {
+		return 1
+	}
+	return c.Heights
+}
+
+func (c *Config) maxLatency() time.Duration {
+	if c.MaxLatency <= 0 {
+		return DefaultMaxLatency
+	}
+	return c.MaxLatency
+}
+
+func (c *Config) delayedLatency() time.Duration {
+	if c.DelayedLatency <= 0 {
+		return DefaultDelayedLatency
+	}
+	return c.DelayedLatency
+}
+
+func (c *Config) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return c.Timeout
+}
+
+// Result is a simulation's outcome
+type Result struct {
+	// Decided maps every operator to its decided value per height
+	Decided map[types.OperatorID]map[qbft.Height][]byte
+	// Duration is the virtual time until all honest operators decided all heights (or the timeout)
+	Duration time.Duration
+}
+
+type heightRound struct {
+	height qbft.Height
+	round  qbft.Round
+}
+
+type heightSigner struct {
+	height qbft.Height
+	signer types.OperatorID
+}
+
+// Simulator runs a committee of controllers, each with its own storage, msg queue and round timers, connected by an in-memory network.
+// Everything runs on a virtual clock in a single goroutine, msgs are delivered with seeded random latencies so every simulation is deterministic.
+type Simulator struct {
+	config     *Config
+	clock      *testingutils.TestingClock
+	rand       *rand.Rand
+	identifier []byte
+	// nodes are sorted by operator id
+	nodes []*node
+	// roundChanges maps height and signer to the signer's highest (encoded) round change msg
+	roundChanges map[heightSigner][]byte
+}
+
+// New returns a simulator for config
+func New(config *Config) *Simulator {
+	identifier := types.NewMsgID(config.KeySet.ValidatorPK.Serialize(), types.BNRoleAttester)
+	sim := &Simulator{
+		config:       config,
+		clock:        testingutils.NewTestingClock(),
+		rand:         rand.New(rand.NewSource(config.Seed)),
+		identifier:   identifier[:],
+		nodes:        make([]*node, 0),
+		roundChanges: make(map[heightSigner][]byte),
+	}
+
+	signer := testingutils.NewTestingKeyManager()
+	ids := make([]types.OperatorID, 0)
+	for id := range config.KeySet.Shares {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		sim.nodes = append(sim.nodes, sim.newNode(id, signer))
+	}
+	return sim
+}
+
+// Run runs the simulation until all honest operators decided all heights or the timeout elapsed.
+// Returns an error if the honest operators violated agreement (decided different values for a height), validity (decided an invalid value) or termination (didn't decide all heights)
+func (sim *Simulator) Run() (*Result, error) {
+	for _, n := range sim.nodes {
+		n := n
+		sim.schedule(sim.latency(), func() {
+			n.startNextInstance()
+		})
+	}
+	for sim.clock.Now() < sim.config.timeout() && !sim.honestDecided() {
+		sim.clock.Advance(step)
+	}
+
+	ret := &Result{
+		Decided:  make(map[types.OperatorID]map[qbft.Height][]byte),
+		Duration: sim.clock.Now(),
+	}
+	for _, n := range sim.nodes {
+		ret.Decided[n.share.OperatorID] = n.decided
+	}
+	return ret, sim.checkProperties()
+}
+
+// honestDecided returns true if all honest operators decided the last height
+func (sim *Simulator) honestDecided() bool {
+	last := qbft.Height(sim.config.heights() - 1)
+	for _, n := range sim.nodes {
+		if n.behavior != Honest {
+			continue
+		}
+		if _, found := n.decided[last]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (sim *Simulator) checkProperties() error {
+	decided := make(map[qbft.Height]*node)
+	for _, n := range sim.nodes {
+		if n.behavior != Honest {
+			continue
+		}
+
+		for height := qbft.FirstHeight; height < qbft.Height(sim.config.heights()); height++ {
+			value, found := n.decided[height]
+			if !found {
+				continue
+			}
+			if err := valueCheck(value); err != nil {
+				return errors.Errorf("validity violated: operator %d decided an invalid value for height %d", n.share.OperatorID, height)
+			}
+			if first, found := decided[height]; found && !bytes.Equal(first.decided[height], value) {
+				return errors.Errorf("agreement violated: operators %d and %d decided different values for height %d", first.share.OperatorID, n.share.OperatorID, height)
+			}
+			decided[height] = n
+		}
+
+		if _, found := n.decided[qbft.Height(sim.config.heights()-1)]; !found {
+			return errors.Errorf("termination violated: operator %d didn't decide all heights", n.share.OperatorID)
+		}
+	}
+	return nil
+}
+
+// valueCheck accepts any value but testingutils.TestingInvalidValueCheck.
+// Empty values are accepted as proposal justifications check the (empty) prepared value of not prepared round changes
+func valueCheck(data []byte) error {
+	if bytes.Equal(data, testingutils.TestingInvalidValueCheck) {
+		return errors.New("invalid value")
+	}
+	return nil
+}
+
+// node is a simulated operator
+type node struct {
+	sim        *Simulator
+	behavior   Behavior
+	share      *types.Share
+	config     *qbft.Config
+	controller *qbft.Controller
+	queue      *qbft.MsgQueue
+	decided    map[qbft.Height][]byte
+	// proposed holds the heights and rounds a WrongProposer operator sent a proposal for
+	proposed map[heightRound]bool
+}
+
+func (sim *Simulator) newNode(id types.OperatorID, signer types.SSVSigner) *node {
+	ks := sim.config.KeySet
+	n := &node{
+		sim:      sim,
+		behavior: sim.config.Behaviors[id],
+		share: &types.Share{
+			OperatorID:      id,
+			ValidatorPubKey: ks.ValidatorPK.Serialize(),
+			SharePubKey:     ks.Shares[id].GetPublicKey().Serialize(),
+			DomainType:      types.PrimusTestnet,
+			Quorum:          ks.Threshold,
+			PartialQuorum:   ks.PartialThreshold,
+			Committee:       ks.Committee(),
+		},
+		decided:  make(map[qbft.Height][]byte),
+		proposed: make(map[heightRound]bool),
+	}
+	n.config = &qbft.Config{
+		Signer:      signer,
+		SigningPK:   n.share.SharePubKey,
+		Domain:      types.PrimusTestnet,
+		ValueCheckF: valueCheck,
+		ProposerF:   qbft.RoundRobinProposer,
+		Storage:     testingutils.NewTestingStorage(),
+		Network:     &network{sim: sim, node: n},
+		InstanceTimerF: func(identifier []byte, height qbft.Height) qbft.Timer {
+			return qbft.NewRoundTimerWithClock(context.Background(), sim.clock, func(round qbft.Round) {
+				n.onTimeout(height, round)
+			})
+		},
+	}
+	n.controller = qbft.NewController(sim.identifier, n.share, types.PrimusTestnet, n.config)
+	n.queue = qbft.NewMsgQueue(n.controller, msgQueueCapacity)
+	return n
+}
+
+// value returns the value the operator starts height with
+func (n *node) value(height qbft.Height) []byte {
+	return []byte{1, 2, byte(height), byte(n.share.OperatorID)}
+}
+
+// startNextInstance starts the instance following the controller's height if it's within the simulated heights
+func (n *node) startNextInstance() {
+	next := n.controller.Height + 1
+	if next >= qbft.Height(n.sim.config.heights()) || n.controller.InstanceForHeight(next) != nil {
+		return
+	}
+	// nolint
+	if err := n.controller.StartNewInstance(n.value(next)); err != nil {
+		return // previous instance not decided yet
+	}
+	n.processReady()
+}
+
+func (n *node) onMsg(byts []byte) {
+	msg := &qbft.SignedMessage{}
+	if err := msg.Decode(byts); err != nil {
+		return
+	}
+	// nolint
+	if err := n.queue.Push(msg); err != nil {
+		return // invalid or stale msg
+	}
+	n.processReady()
+}
+
+func (n *node) onTimeout(height qbft.Height, round qbft.Round) {
+	// nolint
+	_ = n.controller.UponRoundTimeout(height, round)
+	n.processReady()
+}
+
+func (n *node) onSyncedDecided(byts []byte) {
+	msg := &qbft.SignedMessage{}
+	if err := msg.Decode(byts); err != nil {
+		return
+	}
+	decided, err := n.controller.ProcessSyncHighestDecided(msg)
+	if err != nil || decided == nil {
+		return
+	}
+	n.onDecided(decided)
+	n.processReady()
+}
+
+func (n *node) onSyncedRoundChanges(msgs [][]byte) {
+	roundChanges := make([]*qbft.SignedMessage, 0)
+	for _, byts := range msgs {
+		msg := &qbft.SignedMessage{}
+		if err := msg.Decode(byts); err == nil {
+			roundChanges = append(roundChanges, msg)
+		}
+	}
+	// nolint
+	_ = n.controller.ProcessSyncHighestRoundChange(roundChanges)
+	n.processReady()
+}
+
+// processReady processes all ready queued msgs, recording decided values
+func (n *node) processReady() {
+	for _, decided := range n.queue.ProcessReady() {
+		n.onDecided(decided)
+	}
+}
+
+// onDecided records the decided value and starts the next instance
+func (n *node) onDecided(msg *qbft.SignedMessage) {
+	data, err := msg.Message.GetCommitData()
+	if err != nil {
+		return
+	}
+	if _, found := n.decided[msg.Message.Height]; !found {
+		n.decided[msg.Message.Height] = data.Data
+	}
+
+	n.sim.schedule(0, func() {
+		n.startNextInstance()
+	})
+}
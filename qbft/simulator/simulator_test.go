@@ -0,0 +1,73 @@
+package simulator
+
+import (
+	"flag"
+	"fmt"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// run more seeds with: go test ./qbft/simulator -seeds 1000
+var seeds = flag.Int("seeds", 1, "number of seeded runs per simulation")
+
+func TestSimulator(t *testing.T) {
+	keySets := map[string]*testingutils.TestKeySet{
+		"4 operators":  testingutils.Testing4SharesSet(),
+		"7 operators":  testingutils.Testing7SharesSet(),
+		"10 operators": testingutils.Testing10SharesSet(),
+		"13 operators": testingutils.Testing13SharesSet(),
+	}
+	behaviors := []Behavior{Honest, Silent, Equivocating, Delayed, WrongProposer}
+
+	for name, ks := range keySets {
+		f := ks.ShareCount - ks.Threshold
+		for _, behavior := range behaviors {
+			// the first f operators (the first rounds' proposers) behave, the rest are honest
+			config := &Config{
+				KeySet:    ks,
+				Behaviors: make(map[types.OperatorID]Behavior),
+			}
+			for id := types.OperatorID(1); id <= types.OperatorID(f); id++ {
+				config.Behaviors[id] = behavior
+			}
+
+			t.Run(fmt.Sprintf("%s %s", name, behavior), func(t *testing.T) {
+				if testing.Short() && ks.ShareCount > 7 {
+					t.Skip("skipping large committees in short mode")
+				}
+				for seed := 0; seed < *seeds; seed++ {
+					config.Seed = int64(seed)
+					_, err := New(config).Run()
+					require.NoError(t, err, "seed %d", seed)
+				}
+			})
+		}
+	}
+}
+
+func TestSimulator_Deterministic(t *testing.T) {
+	config := &Config{
+		KeySet:    testingutils.Testing4SharesSet(),
+		Behaviors: map[types.OperatorID]Behavior{1: Equivocating},
+		Heights:   2,
+		Seed:      7,
+	}
+	r1, err := New(config).Run()
+	require.NoError(t, err)
+	r2, err := New(config).Run()
+	require.NoError(t, err)
+	require.EqualValues(t, r1, r2)
+}
+
+func TestSimulator_Termination(t *testing.T) {
+	// more than f silent operators prevent any quorum
+	_, err := New(&Config{
+		KeySet:    testingutils.Testing4SharesSet(),
+		Behaviors: map[types.OperatorID]Behavior{1: Silent, 2: Silent},
+		Timeout:   time.Minute,
+	}).Run()
+	require.EqualError(t, err, "termination violated: operator 3 didn't decide all heights")
+}
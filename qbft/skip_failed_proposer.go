@@ -0,0 +1,136 @@
+package qbft
+
+import (
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"sync"
+)
+
+// SkipFailedProposer returns a ProposerF rotating over the committee members for which isFailed returns false.
+// The rotation's starting point is seeded from State.ID and State.Height (as WeightedProposer), if all committee members failed it rotates over the entire committee.
+// isFailed must return the same result on all nodes (see FailedLeaders), otherwise nodes will disagree on the proposer.
+func SkipFailedProposer(isFailed func(state *State, operatorID types.OperatorID) bool) ProposerF {
+	return func(state *State, round Round) types.OperatorID {
+		eligible := make([]*types.Operator, 0)
+		for _, operator := range state.Share.Committee {
+			if !isFailed(state, operator.OperatorID) {
+				eligible = append(eligible, operator)
+			}
+		}
+		if len(eligible) == 0 {
+			eligible = state.Share.Committee
+		}
+
+		length := uint64(len(eligible))
+		index := (proposerSeed(state)%length + uint64(round-FirstRound)%length) % length
+		return eligible[index].OperatorID
+	}
+}
+
+// FailedLeaders tracks leaders which recently failed to drive an instance to a decision.
+// A leader failed if its round didn't decide, meaning the instance decided in a later round.
+// Failures are derived from the lowest decided round recorded for every height, nodes agree on the failures once they recorded decided msgs of the same rounds for the last Window heights.
+// Keeping the lowest round makes nodes converge as decided msgs propagate, a node that missed heights or restarted should rebuild from its decided history (see RecordDecidedHistory).
+// Until nodes converge they might disagree on the proposer, delaying a decision (a proposal from an operator not considered the leader is rejected) without affecting safety.
+// FailedLeaders is safe for concurrent use.
+type FailedLeaders struct {
+	// Window is the number of heights a failure is remembered for
+	Window Height
+
+	lock sync.RWMutex
+	// decidedRounds maps a height to the lowest round it was decided in
+	decidedRounds map[Height]Round
+	// failures maps a height to the leaders that failed at it
+	failures map[Height]map[types.OperatorID]bool
+}
+
+// NewFailedLeaders returns an empty FailedLeaders remembering failures for window heights
+func NewFailedLeaders(window Height) *FailedLeaders {
+	return &FailedLeaders{
+		Window:        window,
+		decidedRounds: make(map[Height]Round),
+		failures:      make(map[Height]map[types.OperatorID]bool),
+	}
+}
+
+// RecordDecided records the leaders (according to proposerF) of all rounds preceding the decided msg's round as failed.
+// A decided msg of a round higher than the one already recorded for its height is ignored
+func (f *FailedLeaders) RecordDecided(share *types.Share, decided *SignedMessage, proposerF ProposerF) {
+	height := decided.Message.Height
+	f.lock.RLock()
+	prev, found := f.decidedRounds[height]
+	f.lock.RUnlock()
+	if found && prev <= decided.Message.Round {
+		return
+	}
+
+	// leaders are calculated without holding the lock as proposerF might call IsFailed
+	state := &State{
+		Share:  share,
+		ID:     decided.Message.Identifier,
+		Height: height,
+	}
+	failed := make(map[types.OperatorID]bool)
+	for round := FirstRound; round < decided.Message.Round; round++ {
+		failed[proposerF(state, round)] = true
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if prev, found := f.decidedRounds[height]; found && prev <= decided.Message.Round {
+		return
+	}
+	f.decidedRounds[height] = decided.Message.Round
+	f.failures[height] = failed
+	f.pruneUnsafe()
+}
+
+// RecordDecidedHistory records the decided msgs found in storage for the Window heights preceding height (see RecordDecided)
+func (f *FailedLeaders) RecordDecidedHistory(storage HistoricalStorage, identifier []byte, share *types.Share, height Height, proposerF ProposerF) error {
+	from := height - f.Window
+	if from < FirstHeight {
+		from = FirstHeight
+	}
+	if height <= from {
+		return nil
+	}
+
+	decided, err := storage.GetDecided(identifier, from, height-1)
+	if err != nil {
+		return errors.Wrap(err, "could not get decided history")
+	}
+	for _, msg := range decided {
+		f.RecordDecided(share, msg, proposerF)
+	}
+	return nil
+}
+
+// IsFailed returns true if the operator failed leading an instance within Window heights before the state's height
+func (f *FailedLeaders) IsFailed(state *State, operatorID types.OperatorID) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	for h := state.Height - 1; h >= FirstHeight && state.Height-h <= f.Window; h-- {
+		if f.failures[h][operatorID] {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneUnsafe removes heights out of the window of the highest recorded height
+func (f *FailedLeaders) pruneUnsafe() {
+	highest := FirstHeight
+	for h := range f.decidedRounds {
+		if h > highest {
+			highest = h
+		}
+	}
+	for h := range f.decidedRounds {
+		if highest-h > f.Window {
+			delete(f.decidedRounds, h)
+			delete(f.failures, h)
+		}
+	}
+}
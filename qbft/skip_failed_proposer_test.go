@@ -0,0 +1,98 @@
+package qbft
+
+import (
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+func TestFailedLeaders(t *testing.T) {
+	share := &types.Share{
+		OperatorID: 1,
+		Committee: []*types.Operator{
+			{OperatorID: 1},
+			{OperatorID: 2},
+			{OperatorID: 3},
+			{OperatorID: 4},
+		},
+	}
+	decidedMsg := func(height Height, round Round) *SignedMessage {
+		return &SignedMessage{
+			Message: &Message{
+				Height:     height,
+				Round:      round,
+				Identifier: []byte{1, 2, 3, 4},
+			},
+		}
+	}
+	state := func(height Height) *State {
+		return &State{Share: share, ID: []byte{1, 2, 3, 4}, Height: height}
+	}
+
+	t.Run("window", func(t *testing.T) {
+		failed := NewFailedLeaders(2)
+
+		// decided in round 3, leaders of rounds 1 and 2 failed
+		failed.RecordDecided(share, decidedMsg(1, 3), RoundRobinProposer)
+
+		require.False(t, failed.IsFailed(state(1), 2)) // same height
+		require.True(t, failed.IsFailed(state(2), 2))
+		require.True(t, failed.IsFailed(state(3), 3))
+		require.False(t, failed.IsFailed(state(4), 2)) // out of window
+		require.False(t, failed.IsFailed(state(2), 1))
+		require.False(t, failed.IsFailed(state(2), 4))
+
+		proposerF := SkipFailedProposer(failed.IsFailed)
+		for round := FirstRound; round < 10; round++ {
+			p := proposerF(state(2), round)
+			require.NotEqualValues(t, types.OperatorID(2), p)
+			require.NotEqualValues(t, types.OperatorID(3), p)
+		}
+	})
+
+	t.Run("lowest decided round", func(t *testing.T) {
+		failed := NewFailedLeaders(2)
+		failed.RecordDecided(share, decidedMsg(1, 3), RoundRobinProposer)
+		require.True(t, failed.IsFailed(state(2), 3))
+
+		// a node that saw a lower decided round converges to it
+		failed.RecordDecided(share, decidedMsg(1, 2), RoundRobinProposer)
+		require.True(t, failed.IsFailed(state(2), 2))
+		require.False(t, failed.IsFailed(state(2), 3))
+
+		// higher rounds are ignored
+		failed.RecordDecided(share, decidedMsg(1, 4), RoundRobinProposer)
+		require.False(t, failed.IsFailed(state(2), 3))
+	})
+
+	t.Run("decided history", func(t *testing.T) {
+		storage := &testingHistoricalStorage{}
+		require.NoError(t, storage.SaveDecided(decidedMsg(1, 3)))
+
+		failed := NewFailedLeaders(2)
+		require.NoError(t, failed.RecordDecidedHistory(storage, []byte{1, 2, 3, 4}, share, 2, RoundRobinProposer))
+		require.True(t, failed.IsFailed(state(2), 2))
+		require.True(t, failed.IsFailed(state(2), 3))
+
+		storage.err = errors.New("db closed")
+		require.EqualError(t, failed.RecordDecidedHistory(storage, []byte{1, 2, 3, 4}, share, 2, RoundRobinProposer), "could not get decided history: db closed")
+	})
+
+	t.Run("proposer records its own failures", func(t *testing.T) {
+		failed := NewFailedLeaders(2)
+		proposerF := SkipFailedProposer(failed.IsFailed)
+
+		var wg sync.WaitGroup
+		for h := Height(1); h < 20; h++ {
+			wg.Add(1)
+			go func(h Height) {
+				defer wg.Done()
+				failed.RecordDecided(share, decidedMsg(h, 2), proposerF)
+				failed.IsFailed(state(h+1), 1)
+			}(h)
+		}
+		wg.Wait()
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/controller/futuremsg"
 	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/controller/latemsg"
 	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/controller/processmsg"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/controller/syncresponse"
 	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/messages"
 	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/prepare"
 	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/proposal"
@@ -89,6 +90,17 @@ var AllTests = []SpecTest{
 	futuremsg.UnknownSigner(),
 	futuremsg.WrongSig(),
 
+	syncresponse.HigherDecided(),
+	syncresponse.NoDecided(),
+	syncresponse.CurrentDecided(),
+	syncresponse.NoQuorumDecided(),
+	syncresponse.WrongIdentifierDecided(),
+	syncresponse.RoundChangesF1(),
+	syncresponse.RoundChangesInvalid(),
+	syncresponse.RoundChangesPostDecided(),
+	syncresponse.RoundChangesHighestF1(),
+	syncresponse.RoundChangesQuorumLeader(),
+
 	startinstance.PostFutureDecided(),
 	startinstance.FirstHeight(),
 	startinstance.PreviousDecided(),
@@ -99,12 +111,22 @@ var AllTests = []SpecTest{
 	proposer.SevenOperators(),
 	proposer.TenOperators(),
 	proposer.ThirteenOperators(),
+	proposer.WeightedFourOperators(),
+	proposer.WeightedEqualScores(),
+	proposer.WeightedSevenOperators(),
+	proposer.WeightedNoScores(),
+	proposer.SkipFailedFourOperators(),
+	proposer.SkipFailedSevenOperators(),
+	proposer.SkipFailedNoFailures(),
+	proposer.SkipFailedAllFailed(),
 
 	messages.RoundChangeDataInvalidJustifications(),
 	messages.RoundChangeDataInvalidPreparedRound(),
 	messages.RoundChangeDataInvalidPreparedValue(),
 	messages.RoundChangePrePreparedJustifications(),
 	messages.RoundChangeNotPreparedJustifications(),
+	messages.RoundChangeDataJustificationsExceedMax(),
+	messages.ProposalDataJustificationsExceedMax(),
 	messages.CommitDataEncoding(),
 	messages.MsgNilIdentifier(),
 	messages.MsgNonZeroIdentifier(),
@@ -132,6 +154,8 @@ var AllTests = []SpecTest{
 	messages.CommitDataInvalid(),
 	messages.ProposalDataInvalid(),
 	messages.SignedMessageSigner0(),
+	messages.SSZEncoding(),
+	messages.SSZJustificationsEncoding(),
 
 	tests.HappyFlow(),
 	tests.SevenOperators(),
@@ -150,6 +174,7 @@ var AllTests = []SpecTest{
 	proposal.DuplicateMsgDifferentValue(),
 	proposal.FirstRoundJustification(),
 	proposal.FutureRoundPrevNotPrepared(),
+	proposal.FutureRoundDecided(),
 	proposal.FutureRound(),
 	proposal.ImparsableProposalData(),
 	proposal.InvalidRoundChangeJustificationPrepared(),
@@ -208,6 +233,10 @@ var AllTests = []SpecTest{
 	commit.UnknownSigner(),
 	commit.InvalidValCheck(),
 	commit.NoPrepareQuorum(),
+	commit.QuorumPastRound(),
+	commit.QuorumFutureRound(),
+	commit.QuorumNoProposal(),
+	commit.QuorumDifferentValue(),
 
 	roundchange.HappyFlow(),
 	roundchange.WrongHeight(),
@@ -245,4 +274,5 @@ var AllTests = []SpecTest{
 	roundchange.QuorumOrder2(),
 	roundchange.QuorumOrder1(),
 	roundchange.QuorumMsgNotPrepared(),
+	roundchange.CompactJustification(),
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/bloxapp/ssv-spec/qbft"
 	tests2 "github.com/bloxapp/ssv-spec/qbft/spectest/tests"
 	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/controller/futuremsg"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/controller/syncresponse"
 	"github.com/bloxapp/ssv-spec/types/testingutils"
 	"github.com/stretchr/testify/require"
 	"io/ioutil"
@@ -100,6 +101,26 @@ func TestJson(t *testing.T) {
 			typedTest := &tests2.RoundRobinSpecTest{}
 			require.NoError(t, json.Unmarshal(byts, &typedTest))
 
+			tests[testName] = typedTest
+			t.Run(typedTest.TestName(), func(t *testing.T) {
+				typedTest.Run(t)
+			})
+		case reflect.TypeOf(&tests2.WeightedProposerSpecTest{}).String():
+			byts, err := json.Marshal(test)
+			require.NoError(t, err)
+			typedTest := &tests2.WeightedProposerSpecTest{}
+			require.NoError(t, json.Unmarshal(byts, &typedTest))
+
+			tests[testName] = typedTest
+			t.Run(typedTest.TestName(), func(t *testing.T) {
+				typedTest.Run(t)
+			})
+		case reflect.TypeOf(&tests2.SkipFailedProposerSpecTest{}).String():
+			byts, err := json.Marshal(test)
+			require.NoError(t, err)
+			typedTest := &tests2.SkipFailedProposerSpecTest{}
+			require.NoError(t, json.Unmarshal(byts, &typedTest))
+
 			tests[testName] = typedTest
 			t.Run(typedTest.TestName(), func(t *testing.T) {
 				typedTest.Run(t)
@@ -110,6 +131,16 @@ func TestJson(t *testing.T) {
 			typedTest := &futuremsg.ControllerSyncSpecTest{}
 			require.NoError(t, json.Unmarshal(byts, &typedTest))
 
+			tests[testName] = typedTest
+			t.Run(typedTest.TestName(), func(t *testing.T) {
+				typedTest.Run(t)
+			})
+		case reflect.TypeOf(&syncresponse.ControllerSyncResponseSpecTest{}).String():
+			byts, err := json.Marshal(test)
+			require.NoError(t, err)
+			typedTest := &syncresponse.ControllerSyncResponseSpecTest{}
+			require.NoError(t, json.Unmarshal(byts, &typedTest))
+
 			tests[testName] = typedTest
 			t.Run(typedTest.TestName(), func(t *testing.T) {
 				typedTest.Run(t)
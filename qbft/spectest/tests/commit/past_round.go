@@ -32,8 +32,7 @@ func PastRound() *tests.MsgProcessingSpecTest {
 	return &tests.MsgProcessingSpecTest{
 		Name:          "commit past round",
 		Pre:           pre,
-		PostRoot:      "02c53d76bdfa84c573386a7dff3e443f120d441b3086f7d5e3834a5c7e1261ab",
+		PostRoot:      "626b1b2023759a8f03a32f4675cee2050eeef380ca85efb660b31f7f2b60d513",
 		InputMessages: msgs,
-		ExpectedError: "commit msg invalid: commit round is wrong",
 	}
 }
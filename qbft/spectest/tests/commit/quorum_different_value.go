@@ -0,0 +1,51 @@
+package commit
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// QuorumDifferentValue tests a commit quorum for a value different than the accepted proposal's (e.g. an equivocating proposer), should decide the quorum's value
+func QuorumDifferentValue() *tests.MsgProcessingSpecTest {
+	pre := testingutils.BaseInstance()
+	pre.State.ProposalAcceptedForCurrentRound = testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+		MsgType:    qbft.ProposalMsgType,
+		Height:     qbft.FirstHeight,
+		Round:      qbft.FirstRound,
+		Identifier: []byte{1, 2, 3, 4},
+		Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil),
+	})
+
+	msgs := []*qbft.SignedMessage{
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[2], types.OperatorID(2), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 5}),
+		}),
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[3], types.OperatorID(3), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 5}),
+		}),
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[4], types.OperatorID(4), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 5}),
+		}),
+	}
+
+	return &tests.MsgProcessingSpecTest{
+		Name:          "commit quorum different value than accepted proposal",
+		Pre:           pre,
+		PostRoot:      "86257809332a767bed85cd53a78a8f8e4d1f67e4c4fc995879b29dcdde1b5920",
+		InputMessages: msgs,
+	}
+}
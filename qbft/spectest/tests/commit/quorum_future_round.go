@@ -0,0 +1,51 @@
+package commit
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// QuorumFutureRound tests a commit quorum for a future round (the instance didn't reach), should decide (formal spec: a commit quorum of any round decides)
+func QuorumFutureRound() *tests.MsgProcessingSpecTest {
+	pre := testingutils.BaseInstance()
+	pre.State.ProposalAcceptedForCurrentRound = testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+		MsgType:    qbft.ProposalMsgType,
+		Height:     qbft.FirstHeight,
+		Round:      qbft.FirstRound,
+		Identifier: []byte{1, 2, 3, 4},
+		Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil),
+	})
+
+	msgs := []*qbft.SignedMessage{
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      3,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		}),
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[2], types.OperatorID(2), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      3,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		}),
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[3], types.OperatorID(3), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      3,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		}),
+	}
+
+	return &tests.MsgProcessingSpecTest{
+		Name:          "commit quorum future round",
+		Pre:           pre,
+		PostRoot:      "16703edaa308e0e3c47470b733b93f8086e944a4dda4eeb52c2683aae9bf913d",
+		InputMessages: msgs,
+	}
+}
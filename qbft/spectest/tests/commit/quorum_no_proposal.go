@@ -0,0 +1,45 @@
+package commit
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// QuorumNoProposal tests a commit quorum for the current round without an accepted proposal (e.g. the proposal was never received), should decide
+func QuorumNoProposal() *tests.MsgProcessingSpecTest {
+	pre := testingutils.BaseInstance()
+	pre.State.ProposalAcceptedForCurrentRound = nil
+
+	msgs := []*qbft.SignedMessage{
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		}),
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[2], types.OperatorID(2), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		}),
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[3], types.OperatorID(3), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		}),
+	}
+
+	return &tests.MsgProcessingSpecTest{
+		Name:          "commit quorum no proposal accepted",
+		Pre:           pre,
+		PostRoot:      "f8fdf25e174e6974faba4df3420bcbe25d4a9894abd6cfcf49a9663f0dee4186",
+		InputMessages: msgs,
+	}
+}
@@ -0,0 +1,52 @@
+package commit
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// QuorumPastRound tests a commit quorum for a past round, should decide (formal spec: a commit quorum of any round decides)
+func QuorumPastRound() *tests.MsgProcessingSpecTest {
+	pre := testingutils.BaseInstance()
+	pre.State.ProposalAcceptedForCurrentRound = testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+		MsgType:    qbft.ProposalMsgType,
+		Height:     qbft.FirstHeight,
+		Round:      5,
+		Identifier: []byte{1, 2, 3, 4},
+		Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil),
+	})
+	pre.State.Round = 5
+
+	msgs := []*qbft.SignedMessage{
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      2,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		}),
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[2], types.OperatorID(2), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      2,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		}),
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[3], types.OperatorID(3), &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      2,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		}),
+	}
+
+	return &tests.MsgProcessingSpecTest{
+		Name:          "commit quorum past round",
+		Pre:           pre,
+		PostRoot:      "50e08c02dd9baf7195b5c782ee0eb6dc60c1755d24c85672f27ee98c0a66b826",
+		InputMessages: msgs,
+	}
+}
@@ -32,6 +32,6 @@ func ImparsableData() *tests.ControllerSpecTest {
 				ControllerPostRoot: "5b6ebc3aa0bfcedd466fca3fca7e1dcc0245def7d61d65aee1462436d819c7d0",
 			},
 		},
-		ExpectedError: "invalid decided msg: invalid decided msg: could not get msg commit data: could not decode commit data from message: invalid character '\\x01' looking for beginning of value",
+		ExpectedError: "invalid decided msg: invalid decided msg: could not get msg commit data: could not decode commit data from message: could not decode ssz commit data: incorrect offset",
 	}
 }
@@ -152,9 +152,8 @@ func LateCommitPastRound() *tests.ControllerSpecTest {
 						Identifier: identifier[:],
 						Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
 					}),
-				ControllerPostRoot: "cf71efe12b0ef2b9c8615ade56d5f969e7fa8a6a1fa0d95c179292825a46fe98",
+				ControllerPostRoot: "9dfda2809069868524330820394de0af92d623a47e33fa42d34578fea43b7416",
 			},
 		},
-		ExpectedError: "could not process msg: commit msg invalid: commit round is wrong",
 	}
 }
@@ -0,0 +1,31 @@
+package syncresponse
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// CurrentDecided tests a synced decided msg for the running instance's height, deciding it
+func CurrentDecided() *ControllerSyncResponseSpecTest {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	ks := testingutils.Testing4SharesSet()
+
+	return &ControllerSyncResponseSpecTest{
+		Name: "current decided",
+		SyncedDecided: testingutils.MultiSignQBFTMsg(
+			[]*bls.SecretKey{ks.Shares[1], ks.Shares[2], ks.Shares[3]},
+			[]types.OperatorID{1, 2, 3},
+			&qbft.Message{
+				MsgType:    qbft.CommitMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      qbft.FirstRound,
+				Identifier: identifier[:],
+				Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+			}),
+		DecidedVal:         []byte{1, 2, 3, 4},
+		ExpectedHeight:     qbft.FirstHeight,
+		ControllerPostRoot: "8a5153ccfbefa992ac8b4af6aad2d050c553a95359d0bc49feaef5c11c7139a2",
+	}
+}
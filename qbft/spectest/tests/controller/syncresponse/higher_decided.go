@@ -0,0 +1,31 @@
+package syncresponse
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// HigherDecided tests a synced decided msg for a higher height fast-forwarding the controller
+func HigherDecided() *ControllerSyncResponseSpecTest {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	ks := testingutils.Testing4SharesSet()
+
+	return &ControllerSyncResponseSpecTest{
+		Name: "higher decided",
+		SyncedDecided: testingutils.MultiSignQBFTMsg(
+			[]*bls.SecretKey{ks.Shares[1], ks.Shares[2], ks.Shares[3]},
+			[]types.OperatorID{1, 2, 3},
+			&qbft.Message{
+				MsgType:    qbft.CommitMsgType,
+				Height:     10,
+				Round:      qbft.FirstRound,
+				Identifier: identifier[:],
+				Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+			}),
+		DecidedVal:         []byte{1, 2, 3, 4},
+		ExpectedHeight:     10,
+		ControllerPostRoot: "c91970b0e33a3b6141567101956dffa63472e56ed041ffdd408ed822973f3caf",
+	}
+}
@@ -0,0 +1,12 @@
+package syncresponse
+
+import "github.com/bloxapp/ssv-spec/qbft"
+
+// NoDecided tests an empty synced decided response (no decided found by peers)
+func NoDecided() *ControllerSyncResponseSpecTest {
+	return &ControllerSyncResponseSpecTest{
+		Name:               "no decided",
+		ExpectedHeight:     qbft.FirstHeight,
+		ControllerPostRoot: "5b6ebc3aa0bfcedd466fca3fca7e1dcc0245def7d61d65aee1462436d819c7d0",
+	}
+}
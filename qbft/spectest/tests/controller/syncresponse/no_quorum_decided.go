@@ -0,0 +1,31 @@
+package syncresponse
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// NoQuorumDecided tests a synced decided msg without a quorum of signers
+func NoQuorumDecided() *ControllerSyncResponseSpecTest {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	ks := testingutils.Testing4SharesSet()
+
+	return &ControllerSyncResponseSpecTest{
+		Name: "no quorum decided",
+		SyncedDecided: testingutils.MultiSignQBFTMsg(
+			[]*bls.SecretKey{ks.Shares[1], ks.Shares[2]},
+			[]types.OperatorID{1, 2},
+			&qbft.Message{
+				MsgType:    qbft.CommitMsgType,
+				Height:     10,
+				Round:      qbft.FirstRound,
+				Identifier: identifier[:],
+				Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+			}),
+		ExpectedHeight:     qbft.FirstHeight,
+		ControllerPostRoot: "5b6ebc3aa0bfcedd466fca3fca7e1dcc0245def7d61d65aee1462436d819c7d0",
+		ExpectedError:      "invalid synced decided msg: not a decided msg",
+	}
+}
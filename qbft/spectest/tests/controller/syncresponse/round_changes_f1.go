@@ -0,0 +1,39 @@
+package syncresponse
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// RoundChangesF1 tests synced f+1 round change msgs for a higher round bumping the running instance's round
+func RoundChangesF1() *ControllerSyncResponseSpecTest {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	ks := testingutils.Testing4SharesSet()
+
+	return &ControllerSyncResponseSpecTest{
+		Name: "round changes f+1",
+		SyncedRoundChanges: []*qbft.SignedMessage{
+			testingutils.SignQBFTMsg(ks.Shares[2], 2, &qbft.Message{
+				MsgType:    qbft.RoundChangeMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      5,
+				Identifier: identifier[:],
+				Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+			}),
+			testingutils.SignQBFTMsg(ks.Shares[3], 3, &qbft.Message{
+				MsgType:    qbft.RoundChangeMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      5,
+				Identifier: identifier[:],
+				Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+			}),
+		},
+		ExpectedHeight: qbft.FirstHeight,
+		ExpectedTimerState: &testingutils.TimerState{
+			Timeouts: 2,
+			Round:    qbft.Round(5),
+		},
+		ControllerPostRoot: "cdb532c484865a5d427217119c4a5e8639a7bc884564a4f922fadd9ce560f30e",
+	}
+}
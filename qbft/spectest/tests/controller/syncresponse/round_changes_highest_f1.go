@@ -0,0 +1,37 @@
+package syncresponse
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// RoundChangesHighestF1 tests synced round change msgs for different rounds jumping to the highest round with f+1 round change msgs of that round or higher (not the lowest)
+func RoundChangesHighestF1() *ControllerSyncResponseSpecTest {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	ks := testingutils.Testing4SharesSet()
+	rc := func(id types.OperatorID, round qbft.Round) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+			MsgType:    qbft.RoundChangeMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      round,
+			Identifier: identifier[:],
+			Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+		})
+	}
+
+	return &ControllerSyncResponseSpecTest{
+		Name: "round changes highest f+1",
+		SyncedRoundChanges: []*qbft.SignedMessage{
+			rc(2, 5),
+			rc(3, 9),
+			rc(4, 7),
+		},
+		ExpectedHeight: qbft.FirstHeight,
+		ExpectedTimerState: &testingutils.TimerState{
+			Timeouts: 2,
+			Round:    qbft.Round(7),
+		},
+		ControllerPostRoot: "22518e406ec08dab891f80b5a9ae11d294f62bf304853c2b766b857db2280c54",
+	}
+}
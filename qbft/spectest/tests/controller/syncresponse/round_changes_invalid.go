@@ -0,0 +1,40 @@
+package syncresponse
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// RoundChangesInvalid tests synced round change msgs where one is invalid, the valid msgs are still processed
+func RoundChangesInvalid() *ControllerSyncResponseSpecTest {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	ks := testingutils.Testing4SharesSet()
+
+	return &ControllerSyncResponseSpecTest{
+		Name: "round changes invalid",
+		SyncedRoundChanges: []*qbft.SignedMessage{
+			testingutils.SignQBFTMsg(ks.Shares[2], 2, &qbft.Message{
+				MsgType:    qbft.RoundChangeMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      5,
+				Identifier: identifier[:],
+				Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+			}),
+			testingutils.SignQBFTMsg(ks.Shares[3], 3, &qbft.Message{
+				MsgType:    qbft.RoundChangeMsgType,
+				Height:     2,
+				Round:      5,
+				Identifier: identifier[:],
+				Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+			}),
+		},
+		ExpectedHeight: qbft.FirstHeight,
+		ExpectedTimerState: &testingutils.TimerState{
+			Timeouts: 1,
+			Round:    qbft.FirstRound,
+		},
+		ControllerPostRoot: "df863fe8893871d648b8289067163a17599388d1f56ef1047dd84800bf73d5ac",
+		ExpectedError:      "invalid synced round change msg: round change Height is wrong",
+	}
+}
@@ -0,0 +1,51 @@
+package syncresponse
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// RoundChangesPostDecided tests synced round change msgs after the running instance decided, which are ignored
+func RoundChangesPostDecided() *ControllerSyncResponseSpecTest {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	ks := testingutils.Testing4SharesSet()
+
+	return &ControllerSyncResponseSpecTest{
+		Name: "round changes post decided",
+		SyncedDecided: testingutils.MultiSignQBFTMsg(
+			[]*bls.SecretKey{ks.Shares[1], ks.Shares[2], ks.Shares[3]},
+			[]types.OperatorID{1, 2, 3},
+			&qbft.Message{
+				MsgType:    qbft.CommitMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      qbft.FirstRound,
+				Identifier: identifier[:],
+				Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+			}),
+		SyncedRoundChanges: []*qbft.SignedMessage{
+			testingutils.SignQBFTMsg(ks.Shares[2], 2, &qbft.Message{
+				MsgType:    qbft.RoundChangeMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      5,
+				Identifier: identifier[:],
+				Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+			}),
+			testingutils.SignQBFTMsg(ks.Shares[3], 3, &qbft.Message{
+				MsgType:    qbft.RoundChangeMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      5,
+				Identifier: identifier[:],
+				Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+			}),
+		},
+		DecidedVal:     []byte{1, 2, 3, 4},
+		ExpectedHeight: qbft.FirstHeight,
+		ExpectedTimerState: &testingutils.TimerState{
+			Timeouts: 1,
+			Round:    qbft.FirstRound,
+		},
+		ControllerPostRoot: "8a5153ccfbefa992ac8b4af6aad2d050c553a95359d0bc49feaef5c11c7139a2",
+	}
+}
@@ -0,0 +1,37 @@
+package syncresponse
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// RoundChangesQuorumLeader tests a synced round change quorum for a higher round led by the node, jumping to that round and proposing at once
+func RoundChangesQuorumLeader() *ControllerSyncResponseSpecTest {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	ks := testingutils.Testing4SharesSet()
+	rc := func(id types.OperatorID, round qbft.Round) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+			MsgType:    qbft.RoundChangeMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      round,
+			Identifier: identifier[:],
+			Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+		})
+	}
+
+	return &ControllerSyncResponseSpecTest{
+		Name: "round changes quorum leader",
+		SyncedRoundChanges: []*qbft.SignedMessage{
+			rc(2, 5),
+			rc(3, 5),
+			rc(4, 5),
+		},
+		ExpectedHeight: qbft.FirstHeight,
+		ExpectedTimerState: &testingutils.TimerState{
+			Timeouts: 2,
+			Round:    qbft.Round(5),
+		},
+		ControllerPostRoot: "826a5ab6b99d68c77bef84a188cdd1d91137913ae2d13b8f6ca91edfb32a9795",
+	}
+}
@@ -0,0 +1,91 @@
+package syncresponse
+
+import (
+	"encoding/hex"
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type ControllerSyncResponseSpecTest struct {
+	Name               string
+	SyncedDecided      *qbft.SignedMessage
+	SyncedRoundChanges []*qbft.SignedMessage
+	DecidedVal         []byte
+	ExpectedHeight     qbft.Height
+	ExpectedTimerState *testingutils.TimerState
+	ControllerPostRoot string
+	ExpectedError      string
+	// ExpectedLogs are the non-fatal errors expected to be logged, in order
+	ExpectedLogs []string
+}
+
+func (test *ControllerSyncResponseSpecTest) TestName() string {
+	return "qbft controller sync response " + test.Name
+}
+
+func (test *ControllerSyncResponseSpecTest) Run(t *testing.T) {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	config := testingutils.TestingConfig(testingutils.Testing4SharesSet())
+	contr := testingutils.NewTestingQBFTController(
+		identifier[:],
+		testingutils.TestingShare(testingutils.Testing4SharesSet()),
+		config,
+	)
+
+	err := contr.StartNewInstance([]byte{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	var lastErr error
+	decided, err := contr.ProcessSyncHighestDecided(test.SyncedDecided)
+	if err != nil {
+		lastErr = err
+	}
+	if len(test.DecidedVal) != 0 {
+		require.NotNil(t, decided)
+		data, err := decided.Message.GetCommitData()
+		require.NoError(t, err)
+		require.EqualValues(t, test.DecidedVal, data.Data)
+	} else {
+		require.Nil(t, decided)
+	}
+
+	if len(test.SyncedRoundChanges) > 0 {
+		if err := contr.ProcessSyncHighestRoundChange(test.SyncedRoundChanges); err != nil {
+			lastErr = err
+		}
+	}
+
+	require.EqualValues(t, test.ExpectedHeight, contr.Height)
+
+	if test.ExpectedTimerState != nil {
+		timer, ok := config.GetTimer().(*testingutils.TestQBFTTimer)
+		require.True(t, ok, "timer should be a TestQBFTTimer")
+		require.NotNil(t, timer)
+		require.Equal(t, test.ExpectedTimerState.Timeouts, timer.State.Timeouts, "timer should have expected timeouts count")
+		require.Equal(t, test.ExpectedTimerState.Round, timer.State.Round, "timer should have expected round")
+	}
+
+	r, err := contr.GetRoot()
+	require.NoError(t, err)
+	require.EqualValues(t, test.ControllerPostRoot, hex.EncodeToString(r))
+
+	// non-fatal errors
+	logger, ok := config.GetLogger().(*testingutils.TestingLogger)
+	require.True(t, ok, "logger should be a TestingLogger")
+	if len(test.ExpectedLogs) != 0 {
+		require.EqualValues(t, test.ExpectedLogs, logger.Errors())
+	} else {
+		require.Empty(t, logger.Errors())
+	}
+
+	if len(test.ExpectedError) != 0 {
+		require.EqualError(t, lastErr, test.ExpectedError)
+	} else {
+		require.NoError(t, lastErr)
+	}
+}
@@ -27,11 +27,18 @@ type ControllerSpecTest struct {
 	RunInstanceData []*RunInstanceData
 	OutputMessages  []*qbft.SignedMessage
 	ExpectedError   string
+	// ExpectedLogs are the non-fatal errors expected to be logged, in order
+	ExpectedLogs []string
 }
 
 func (test *ControllerSpecTest) Run(t *testing.T) {
 	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
 	config := testingutils.TestingConfig(testingutils.Testing4SharesSet())
+	// safety invariants must hold for every state transition
+	violations := make([]*qbft.InvariantViolation, 0)
+	config.InvariantViolationF = func(violation *qbft.InvariantViolation) {
+		violations = append(violations, violation)
+	}
 	contr := testingutils.NewTestingQBFTController(
 		identifier[:],
 		testingutils.TestingShare(testingutils.Testing4SharesSet()),
@@ -114,6 +121,16 @@ func (test *ControllerSpecTest) Run(t *testing.T) {
 		require.EqualValues(t, runData.ControllerPostRoot, hex.EncodeToString(r))
 	}
 
+	// non-fatal errors
+	logger, ok := config.GetLogger().(*testingutils.TestingLogger)
+	require.True(t, ok, "logger should be a TestingLogger")
+	if len(test.ExpectedLogs) != 0 {
+		require.EqualValues(t, test.ExpectedLogs, logger.Errors())
+	} else {
+		require.Empty(t, logger.Errors())
+	}
+	require.Empty(t, violations)
+
 	if len(test.ExpectedError) != 0 {
 		require.EqualError(t, lastErr, test.ExpectedError)
 	} else {
@@ -59,6 +59,6 @@ func CreateProposalPreviouslyPrepared() *tests.CreateMsgSpecTest {
 				Data:       testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}),
 			}),
 		},
-		ExpectedRoot: "15a4700a55577a524a147d348d35aaeafbd04b478ba963988e7af3647b9dbb8c",
+		ExpectedRoot: "19f1fe7830fdf608bd0928662d787b3cc853db0af8cc9702f1b49ef15d309917",
 	}
 }
@@ -36,6 +36,6 @@ func CreateRoundChangePreviouslyPrepared() *tests.CreateMsgSpecTest {
 				Data:       testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}),
 			}),
 		},
-		ExpectedRoot: "de421aca0c42404cea60b6a9dea40457831eed611b78dd529e022fc54e81af44",
+		ExpectedRoot: "cf20df5b81584ebd3085df440eb3a75fd201487ae8caa59834d36dfc6b61ec24",
 	}
 }
@@ -0,0 +1,38 @@
+package messages
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// ProposalDataJustificationsExceedMax tests len(PrepareJustification) > MaxJustificationMsgs
+func ProposalDataJustificationsExceedMax() *tests.MsgSpecTest {
+	prepareMsgs := make([]*qbft.SignedMessage, 0)
+	for i := 0; i <= qbft.MaxJustificationMsgs; i++ {
+		prepareMsgs = append(prepareMsgs, testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+			MsgType:    qbft.PrepareMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}),
+		}))
+	}
+
+	msg := testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+		MsgType:    qbft.ProposalMsgType,
+		Height:     qbft.FirstHeight,
+		Round:      2,
+		Identifier: []byte{1, 2, 3, 4},
+		Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, prepareMsgs),
+	})
+
+	return &tests.MsgSpecTest{
+		Name: "proposal justifications exceed max",
+		Messages: []*qbft.SignedMessage{
+			msg,
+		},
+		ExpectedError: "ProposalData prepare justification exceeds max msgs",
+	}
+}
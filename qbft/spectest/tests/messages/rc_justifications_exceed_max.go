@@ -0,0 +1,38 @@
+package messages
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// RoundChangeDataJustificationsExceedMax tests len(RoundChangeJustification) > MaxJustificationMsgs
+func RoundChangeDataJustificationsExceedMax() *tests.MsgSpecTest {
+	prepareMsgs := make([]*qbft.SignedMessage, 0)
+	for i := 0; i <= qbft.MaxJustificationMsgs; i++ {
+		prepareMsgs = append(prepareMsgs, testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+			MsgType:    qbft.PrepareMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}),
+		}))
+	}
+
+	msg := testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+		MsgType:    qbft.RoundChangeMsgType,
+		Height:     qbft.FirstHeight,
+		Round:      10,
+		Identifier: []byte{1, 2, 3, 4},
+		Data:       testingutils.RoundChangePreparedDataBytes([]byte{1, 2, 3, 4}, qbft.FirstRound, prepareMsgs),
+	})
+
+	return &tests.MsgSpecTest{
+		Name: "rc justifications exceed max",
+		Messages: []*qbft.SignedMessage{
+			msg,
+		},
+		ExpectedError: "round change justification exceeds max msgs",
+	}
+}
@@ -0,0 +1,56 @@
+package messages
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// SSZEncoding tests encoding SignedMessage with both encoding versions
+func SSZEncoding() *tests.MsgSpecTest {
+	msgs := []*qbft.SignedMessage{
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+			MsgType:    qbft.PrepareMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}),
+		}),
+		testingutils.MultiSignQBFTMsg(
+			[]*bls.SecretKey{testingutils.Testing4SharesSet().Shares[1], testingutils.Testing4SharesSet().Shares[2], testingutils.Testing4SharesSet().Shares[3]},
+			[]types.OperatorID{1, 2, 3},
+			&qbft.Message{
+				MsgType:    qbft.CommitMsgType,
+				Height:     10,
+				Round:      2,
+				Identifier: []byte{1, 2, 3, 4},
+				Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+			}),
+	}
+
+	encoded := make([][]byte, 0)
+	roots := make([][]byte, 0)
+	sszEncoded := make([][]byte, 0)
+	sszRoots := make([][]byte, 0)
+	for _, msg := range msgs {
+		b, _ := msg.EncodeWithVersion(qbft.JSONEncodingVersion)
+		r, _ := msg.GetRootWithVersion(qbft.JSONEncodingVersion)
+		sszB, _ := msg.EncodeWithVersion(qbft.SSZEncodingVersion)
+		sszR, _ := msg.GetRootWithVersion(qbft.SSZEncodingVersion)
+		encoded = append(encoded, b)
+		roots = append(roots, r)
+		sszEncoded = append(sszEncoded, sszB)
+		sszRoots = append(sszRoots, sszR)
+	}
+
+	return &tests.MsgSpecTest{
+		Name:               "ssz encoding",
+		Messages:           msgs,
+		EncodedMessages:    encoded,
+		ExpectedRoots:      roots,
+		SSZEncodedMessages: sszEncoded,
+		SSZExpectedRoots:   sszRoots,
+	}
+}
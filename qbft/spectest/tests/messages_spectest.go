@@ -12,7 +12,10 @@ type MsgSpecTest struct {
 	Messages        []*qbft.SignedMessage
 	EncodedMessages [][]byte
 	ExpectedRoots   [][]byte
-	ExpectedError   string
+	// SSZEncodedMessages and SSZExpectedRoots are the msgs' encoding and roots with qbft.SSZEncodingVersion
+	SSZEncodedMessages [][]byte
+	SSZExpectedRoots   [][]byte
+	ExpectedError      string
 }
 
 func (test *MsgSpecTest) Run(t *testing.T) {
@@ -70,6 +73,24 @@ func (test *MsgSpecTest) Run(t *testing.T) {
 			require.NoError(t, err)
 			require.EqualValues(t, test.ExpectedRoots[i], r)
 		}
+
+		if len(test.SSZEncodedMessages) > 0 {
+			byts, err := msg.EncodeWithVersion(qbft.SSZEncodingVersion)
+			require.NoError(t, err)
+			require.EqualValues(t, test.SSZEncodedMessages[i], byts)
+
+			decoded := &qbft.SignedMessage{}
+			require.NoError(t, decoded.DecodeWithVersion(qbft.SSZEncodingVersion, byts))
+			decodedByts, err := decoded.EncodeWithVersion(qbft.SSZEncodingVersion)
+			require.NoError(t, err)
+			require.EqualValues(t, byts, decodedByts)
+		}
+
+		if len(test.SSZExpectedRoots) > 0 {
+			r, err := msg.GetRootWithVersion(qbft.SSZEncodingVersion)
+			require.NoError(t, err)
+			require.EqualValues(t, test.SSZExpectedRoots[i], r)
+		}
 	}
 
 	// check error
@@ -21,6 +21,8 @@ type MsgProcessingSpecTest struct {
 	OutputMessages     []*qbft.SignedMessage
 	ExpectedError      string
 	ExpectedTimerState *testingutils.TimerState
+	// ExpectedLogs are the non-fatal errors expected to be logged, in order
+	ExpectedLogs []string
 }
 
 func (test *MsgProcessingSpecTest) Run(t *testing.T) {
@@ -31,6 +33,12 @@ func (test *MsgProcessingSpecTest) Run(t *testing.T) {
 		}
 	}
 
+	// safety invariants must hold for every state transition
+	violations := make([]*qbft.InvariantViolation, 0)
+	test.Pre.GetConfig().(*qbft.Config).InvariantViolationF = func(violation *qbft.InvariantViolation) {
+		violations = append(violations, violation)
+	}
+
 	var lastErr error
 	for _, msg := range test.InputMessages {
 		_, _, _, err := test.Pre.ProcessMsg(msg)
@@ -39,6 +47,16 @@ func (test *MsgProcessingSpecTest) Run(t *testing.T) {
 		}
 	}
 
+	// non-fatal errors
+	logger, ok := test.Pre.GetConfig().GetLogger().(*testingutils.TestingLogger)
+	require.True(t, ok, "logger should be a TestingLogger")
+	if len(test.ExpectedLogs) != 0 {
+		require.EqualValues(t, test.ExpectedLogs, logger.Errors())
+	} else {
+		require.Empty(t, logger.Errors())
+	}
+	require.Empty(t, violations)
+
 	if len(test.ExpectedError) != 0 {
 		require.EqualError(t, lastErr, test.ExpectedError)
 	} else {
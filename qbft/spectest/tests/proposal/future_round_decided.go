@@ -0,0 +1,95 @@
+package proposal
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// FutureRoundDecided tests a justified proposal for a future round of an instance prepared in its current round, moving to the proposal's round then preparing and deciding in it
+func FutureRoundDecided() *tests.MsgProcessingSpecTest {
+	pre := testingutils.BaseInstance()
+	pre.State.Round = qbft.FirstRound
+	pre.State.LastPreparedRound = qbft.FirstRound
+	pre.State.LastPreparedValue = []byte{1, 2, 3, 4}
+
+	rcMsgs := []*qbft.SignedMessage{
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[2], types.OperatorID(2), &qbft.Message{
+			MsgType:    qbft.RoundChangeMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      3,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+		}),
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[3], types.OperatorID(3), &qbft.Message{
+			MsgType:    qbft.RoundChangeMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      3,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+		}),
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[4], types.OperatorID(4), &qbft.Message{
+			MsgType:    qbft.RoundChangeMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      3,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+		}),
+	}
+
+	msgs := []*qbft.SignedMessage{
+		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+			MsgType:    qbft.ProposalMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      3,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, rcMsgs, nil),
+		}),
+	}
+	for _, id := range []types.OperatorID{2, 3, 4} {
+		msgs = append(msgs, testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[id], id, &qbft.Message{
+			MsgType:    qbft.PrepareMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      3,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}),
+		}))
+	}
+	for _, id := range []types.OperatorID{2, 3, 4} {
+		msgs = append(msgs, testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[id], id, &qbft.Message{
+			MsgType:    qbft.CommitMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      3,
+			Identifier: []byte{1, 2, 3, 4},
+			Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+		}))
+	}
+
+	return &tests.MsgProcessingSpecTest{
+		Name:          "proposal future round decided",
+		Pre:           pre,
+		PostRoot:      "a6cbefbbd8166028481c95f2b9f39ca9fbd6c15d9e44d1e2278121adc8d2fce5",
+		InputMessages: msgs,
+		OutputMessages: []*qbft.SignedMessage{
+			testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+				MsgType:    qbft.PrepareMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      3,
+				Identifier: []byte{1, 2, 3, 4},
+				Data:       testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}),
+			}),
+			testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
+				MsgType:    qbft.CommitMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      3,
+				Identifier: []byte{1, 2, 3, 4},
+				Data:       testingutils.CommitDataBytes([]byte{1, 2, 3, 4}),
+			}),
+		},
+		ExpectedTimerState: &testingutils.TimerState{
+			Timeouts: 1,
+			Round:    qbft.Round(3),
+		},
+	}
+}
@@ -25,6 +25,6 @@ func InvalidProposalData() *tests.MsgProcessingSpecTest {
 		PostRoot:       "3e721f04a2a64737ec96192d59e90dfdc93f166ec9a21b88cc33ee0c43f2b26a",
 		InputMessages:  msgs,
 		OutputMessages: []*qbft.SignedMessage{},
-		ExpectedError:  "proposal invalid: could not get proposal data: could not decode proposal data from message: invalid character '\\x01' looking for beginning of value",
+		ExpectedError:  "proposal invalid: could not get proposal data: could not decode proposal data from message: could not decode ssz proposal data: incorrect size",
 	}
 }
@@ -0,0 +1,87 @@
+package proposer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// skipFailedSpecTest returns a SkipFailedProposerSpecTest expecting a rotation over the eligible (not failed) operators, starting at the index seeded from the identifier and height
+func skipFailedSpecTest(name string, share *types.Share, failed []types.OperatorID, eligible []types.OperatorID) *tests.SkipFailedProposerSpecTest {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	heights := make([]qbft.Height, 0)
+	rounds := make([]qbft.Round, 0)
+	proposers := make([]types.OperatorID, 0)
+	for h := qbft.FirstHeight; h < 100; h++ {
+		idx := int(proposerSeed(identifier[:], h) % uint64(len(eligible)))
+		for r := qbft.FirstRound; r < 100; r++ {
+			heights = append(heights, h)
+			rounds = append(rounds, r)
+			proposers = append(proposers, eligible[idx])
+
+			idx = (idx + 1) % len(eligible)
+		}
+	}
+
+	return &tests.SkipFailedProposerSpecTest{
+		Name:       name,
+		Share:      share,
+		Identifier: identifier[:],
+		Failed:     failed,
+		Heights:    heights,
+		Rounds:     rounds,
+		Proposers:  proposers,
+	}
+}
+
+// proposerSeed returns the proposer rotation seed, the first 8 bytes (big endian) of sha256(identifier || big endian height)
+func proposerSeed(identifier []byte, height qbft.Height) uint64 {
+	heightByts := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightByts, uint64(height))
+
+	h := sha256.Sum256(append(append([]byte{}, identifier...), heightByts...))
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+// SkipFailedFourOperators tests skipping a failed operator for 4 member committee
+func SkipFailedFourOperators() *tests.SkipFailedProposerSpecTest {
+	return skipFailedSpecTest(
+		"4 member committee",
+		testingutils.TestingShare(testingutils.Testing4SharesSet()),
+		[]types.OperatorID{2},
+		[]types.OperatorID{1, 3, 4},
+	)
+}
+
+// SkipFailedSevenOperators tests skipping failed operators for 7 member committee
+func SkipFailedSevenOperators() *tests.SkipFailedProposerSpecTest {
+	return skipFailedSpecTest(
+		"7 member committee",
+		testingutils.TestingShare(testingutils.Testing7SharesSet()),
+		[]types.OperatorID{1, 5},
+		[]types.OperatorID{2, 3, 4, 6, 7},
+	)
+}
+
+// SkipFailedNoFailures tests skip failed proposer without failures, rotating over the entire committee
+func SkipFailedNoFailures() *tests.SkipFailedProposerSpecTest {
+	return skipFailedSpecTest(
+		"no failures",
+		testingutils.TestingShare(testingutils.Testing4SharesSet()),
+		[]types.OperatorID{},
+		[]types.OperatorID{1, 2, 3, 4},
+	)
+}
+
+// SkipFailedAllFailed tests skip failed proposer when all operators failed, rotating over the entire committee
+func SkipFailedAllFailed() *tests.SkipFailedProposerSpecTest {
+	return skipFailedSpecTest(
+		"all failed",
+		testingutils.TestingShare(testingutils.Testing4SharesSet()),
+		[]types.OperatorID{1, 2, 3, 4},
+		[]types.OperatorID{1, 2, 3, 4},
+	)
+}
@@ -0,0 +1,107 @@
+package proposer
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// weightedSpecTest flattens expected proposers (per height, for rounds 1..len) into a WeightedProposerSpecTest
+func weightedSpecTest(name string, share *types.Share, scores map[types.OperatorID]uint64, expected [][]types.OperatorID) *tests.WeightedProposerSpecTest {
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	heights := make([]qbft.Height, 0)
+	rounds := make([]qbft.Round, 0)
+	proposers := make([]types.OperatorID, 0)
+	for h, roundProposers := range expected {
+		for i, p := range roundProposers {
+			heights = append(heights, qbft.Height(h))
+			rounds = append(rounds, qbft.FirstRound+qbft.Round(i))
+			proposers = append(proposers, p)
+		}
+	}
+
+	return &tests.WeightedProposerSpecTest{
+		Name:       name,
+		Share:      share,
+		Identifier: identifier[:],
+		Scores:     scores,
+		Heights:    heights,
+		Rounds:     rounds,
+		Proposers:  proposers,
+	}
+}
+
+// WeightedFourOperators tests weighted proposer selection for 4 member committee, operator 1 proposes 3 out of every 5 rounds while operator 4 never proposes
+func WeightedFourOperators() *tests.WeightedProposerSpecTest {
+	return weightedSpecTest(
+		"4 member committee",
+		testingutils.TestingShare(testingutils.Testing4SharesSet()),
+		map[types.OperatorID]uint64{1: 3, 2: 1, 3: 1, 4: 0},
+		[][]types.OperatorID{
+			{1, 3, 1, 1, 2, 1, 3, 1},
+			{3, 1, 1, 2, 1, 3, 1, 1},
+			{2, 1, 3, 1, 1, 2, 1, 3},
+			{3, 1, 1, 2, 1, 3, 1, 1},
+			{1, 3, 1, 1, 2, 1, 3, 1},
+		},
+	)
+}
+
+// WeightedEqualScores tests weighted proposer selection with equal scores, rotating the committee in order from a seeded starting point
+func WeightedEqualScores() *tests.WeightedProposerSpecTest {
+	return weightedSpecTest(
+		"equal scores",
+		testingutils.TestingShare(testingutils.Testing4SharesSet()),
+		map[types.OperatorID]uint64{1: 10, 2: 10, 3: 10, 4: 10},
+		[][]types.OperatorID{
+			{2, 3, 4, 1, 2, 3, 4, 1},
+			{1, 2, 3, 4, 1, 2, 3, 4},
+			{2, 3, 4, 1, 2, 3, 4, 1},
+			{1, 2, 3, 4, 1, 2, 3, 4},
+			{1, 2, 3, 4, 1, 2, 3, 4},
+		},
+	)
+}
+
+// WeightedSevenOperators tests weighted proposer selection for 7 member committee
+func WeightedSevenOperators() *tests.WeightedProposerSpecTest {
+	return weightedSpecTest(
+		"7 member committee",
+		testingutils.TestingShare(testingutils.Testing7SharesSet()),
+		map[types.OperatorID]uint64{1: 100, 2: 50, 3: 50, 4: 25, 5: 25, 6: 0, 7: 50},
+		[][]types.OperatorID{
+			{4, 5, 1, 2, 3, 7, 1, 1},
+			{2, 3, 7, 1, 1, 2, 3, 7},
+			{2, 3, 7, 1, 4, 5, 1, 2},
+			{1, 4, 5, 1, 2, 3, 7, 1},
+			{2, 3, 7, 1, 1, 2, 3, 7},
+		},
+	)
+}
+
+// WeightedNoScores tests weighted proposer selection without any scores, falling back to round-robin
+func WeightedNoScores() *tests.WeightedProposerSpecTest {
+	var p types.OperatorID
+	expected := make([][]types.OperatorID, 0)
+	for h := qbft.FirstHeight; h < 10; h++ {
+		roundProposers := make([]types.OperatorID, 0)
+		p = types.OperatorID(h%4) + 1
+		for r := qbft.FirstRound; r < 10; r++ {
+			roundProposers = append(roundProposers, p)
+
+			p++
+			if p == 5 {
+				p = 1
+			}
+		}
+		expected = append(expected, roundProposers)
+	}
+
+	return weightedSpecTest(
+		"no scores",
+		testingutils.TestingShare(testingutils.Testing4SharesSet()),
+		map[types.OperatorID]uint64{},
+		expected,
+	)
+}
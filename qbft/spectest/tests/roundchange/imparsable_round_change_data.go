@@ -28,6 +28,6 @@ func ImparsableRoundChangeData() *tests.MsgProcessingSpecTest {
 		PostRoot:       "4aafcc4aa9e2435579c85aa26e659fe650aefb8becb5738d32dd9286f7ff27c3",
 		InputMessages:  msgs,
 		OutputMessages: []*qbft.SignedMessage{},
-		ExpectedError:  "round change msg invalid: could not get roundChange data : could not decode round change data from message: invalid character '\\x01' looking for beginning of value",
+		ExpectedError:  "round change msg invalid: could not get roundChange data : could not decode round change data from message: could not decode ssz round change data: incorrect size",
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/bloxapp/ssv-spec/qbft/spectest/tests"
 	"github.com/bloxapp/ssv-spec/types"
 	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
 )
 
 // PeerPreparedDifferentHeights tests a round change quorum where peers prepared on different heights
@@ -58,6 +59,19 @@ func PeerPreparedDifferentHeights() *tests.MsgProcessingSpecTest {
 			Data:       testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}),
 		}),
 	}
+	// prepareMsgs2 as the instance justifies with them, aggregated into a single multi signer msg
+	aggregatedPrepareMsgs2 := []*qbft.SignedMessage{
+		testingutils.MultiSignQBFTMsg(
+			[]*bls.SecretKey{testingutils.Testing4SharesSet().Shares[1], testingutils.Testing4SharesSet().Shares[2], testingutils.Testing4SharesSet().Shares[3]},
+			[]types.OperatorID{1, 2, 3},
+			&qbft.Message{
+				MsgType:    qbft.PrepareMsgType,
+				Height:     qbft.FirstHeight,
+				Round:      2,
+				Identifier: []byte{1, 2, 3, 4},
+				Data:       testingutils.PrepareDataBytes([]byte{1, 2, 3, 4}),
+			}),
+	}
 	msgs := []*qbft.SignedMessage{
 		testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], types.OperatorID(1), &qbft.Message{
 			MsgType:    qbft.RoundChangeMsgType,
@@ -93,7 +107,7 @@ func PeerPreparedDifferentHeights() *tests.MsgProcessingSpecTest {
 				Height:     qbft.FirstHeight,
 				Round:      3,
 				Identifier: []byte{1, 2, 3, 4},
-				Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, msgs, prepareMsgs2),
+				Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, msgs, aggregatedPrepareMsgs2),
 			}),
 		},
 	}
@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type SkipFailedProposerSpecTest struct {
+	Name       string
+	Share      *types.Share
+	Identifier []byte
+	Failed     []types.OperatorID
+	Heights    []qbft.Height
+	Rounds     []qbft.Round
+	Proposers  []types.OperatorID
+}
+
+func (test *SkipFailedProposerSpecTest) Run(t *testing.T) {
+	require.True(t, len(test.Heights) > 0)
+	proposerF := qbft.SkipFailedProposer(func(state *qbft.State, operatorID types.OperatorID) bool {
+		for _, failed := range test.Failed {
+			if failed == operatorID {
+				return true
+			}
+		}
+		return false
+	})
+	for i, h := range test.Heights {
+		r := test.Rounds[i]
+		s := &qbft.State{
+			ID:     test.Identifier,
+			Height: h,
+			Round:  r,
+			Share:  test.Share,
+		}
+
+		require.EqualValues(t, test.Proposers[i], proposerF(s, r))
+	}
+}
+
+func (test *SkipFailedProposerSpecTest) TestName() string {
+	return "qbft skip failed proposer " + test.Name
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type WeightedProposerSpecTest struct {
+	Name       string
+	Share      *types.Share
+	Identifier []byte
+	Scores     map[types.OperatorID]uint64
+	Heights    []qbft.Height
+	Rounds     []qbft.Round
+	Proposers  []types.OperatorID
+}
+
+func (test *WeightedProposerSpecTest) Run(t *testing.T) {
+	require.True(t, len(test.Heights) > 0)
+	proposerF := qbft.WeightedProposer(test.Scores)
+	for i, h := range test.Heights {
+		r := test.Rounds[i]
+		s := &qbft.State{
+			ID:     test.Identifier,
+			Height: h,
+			Round:  r,
+			Share:  test.Share,
+		}
+
+		require.EqualValues(t, test.Proposers[i], proposerF(s, r))
+	}
+}
+
+func (test *WeightedProposerSpecTest) TestName() string {
+	return "qbft weighted proposer " + test.Name
+}
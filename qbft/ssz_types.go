@@ -0,0 +1,81 @@
+package qbft
+
+//go:generate sszgen --path ssz_types.go --objs MessageSSZ,SignedMessageSSZ,ProposalDataSSZ,PrepareDataSSZ,CommitDataSSZ,RoundChangeDataSSZ,PrepareJustificationMessageSSZ,PrepareJustificationSSZ,RoundChangeJustificationMessageSSZ,RoundChangeJustificationSSZ --output ssz_types_encoding.go
+
+// SSZ size limits, each nested limit is derived from the max encoded size of what it carries so every valid msg fits its enclosing limit:
+//  - a value is at most 1048576 bytes, prepare and commit data at most 4 + 1048576 = 1048580 bytes
+//  - a signed msg adds at most 296 bytes to its data (96 signature, 13 * 8 signers, 56 identifier and 48 fixed fields and offsets), a signed prepare is at most 1048876 bytes
+//  - round change data is at most 16 + 1048576 + 13 * (4 + 1048876) = 14684032 bytes, a signed round change at most 14684328 bytes
+//  - proposal data is at most 12 + 1048576 + 13 * (4 + 14684328) + 13 * (4 + 1048876) = 205580344 bytes, the max data of any msg
+
+// MessageSSZ is the SSZ representation of Message
+type MessageSSZ struct {
+	MsgType    uint64
+	Height     uint64
+	Round      uint64
+	Identifier []byte `ssz-max:"56"`
+	Data       []byte `ssz-max:"205580344"`
+}
+
+// SignedMessageSSZ is the SSZ representation of SignedMessage
+type SignedMessageSSZ struct {
+	Signature []byte   `ssz-size:"96"`
+	Signers   []uint64 `ssz-max:"13"`
+	Message   *MessageSSZ
+}
+
+// PrepareJustificationMessageSSZ is the SSZ representation of a prepare Message justifying a round change or proposal
+type PrepareJustificationMessageSSZ struct {
+	MsgType    uint64
+	Height     uint64
+	Round      uint64
+	Identifier []byte `ssz-max:"56"`
+	Data       []byte `ssz-max:"1048580"`
+}
+
+// PrepareJustificationSSZ is the SSZ representation of a prepare SignedMessage justifying a round change or proposal
+type PrepareJustificationSSZ struct {
+	Signature []byte   `ssz-size:"96"`
+	Signers   []uint64 `ssz-max:"13"`
+	Message   *PrepareJustificationMessageSSZ
+}
+
+// RoundChangeJustificationMessageSSZ is the SSZ representation of a round change Message justifying a proposal
+type RoundChangeJustificationMessageSSZ struct {
+	MsgType    uint64
+	Height     uint64
+	Round      uint64
+	Identifier []byte `ssz-max:"56"`
+	Data       []byte `ssz-max:"14684032"`
+}
+
+// RoundChangeJustificationSSZ is the SSZ representation of a round change SignedMessage justifying a proposal
+type RoundChangeJustificationSSZ struct {
+	Signature []byte   `ssz-size:"96"`
+	Signers   []uint64 `ssz-max:"13"`
+	Message   *RoundChangeJustificationMessageSSZ
+}
+
+// ProposalDataSSZ is the SSZ representation of ProposalData
+type ProposalDataSSZ struct {
+	Data                     []byte                         `ssz-max:"1048576"`
+	RoundChangeJustification []*RoundChangeJustificationSSZ `ssz-max:"13"`
+	PrepareJustification     []*PrepareJustificationSSZ     `ssz-max:"13"`
+}
+
+// PrepareDataSSZ is the SSZ representation of PrepareData
+type PrepareDataSSZ struct {
+	Data []byte `ssz-max:"1048576"`
+}
+
+// CommitDataSSZ is the SSZ representation of CommitData
+type CommitDataSSZ struct {
+	Data []byte `ssz-max:"1048576"`
+}
+
+// RoundChangeDataSSZ is the SSZ representation of RoundChangeData
+type RoundChangeDataSSZ struct {
+	PreparedValue            []byte `ssz-max:"1048576"`
+	PreparedRound            uint64
+	RoundChangeJustification []*PrepareJustificationSSZ `ssz-max:"13"`
+}
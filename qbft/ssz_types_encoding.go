@@ -0,0 +1,1573 @@
+// Code generated by fastssz. DO NOT EDIT.
+// Hash: e608e62cc37edbeed63aee9093222e3b66f19cadb83d922bbe53ffbe439354ba
+package qbft
+
+import (
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// MarshalSSZ ssz marshals the MessageSSZ object
+func (m *MessageSSZ) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(m)
+}
+
+// MarshalSSZTo ssz marshals the MessageSSZ object to a target array
+func (m *MessageSSZ) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(32)
+
+	// Field (0) 'MsgType'
+	dst = ssz.MarshalUint64(dst, m.MsgType)
+
+	// Field (1) 'Height'
+	dst = ssz.MarshalUint64(dst, m.Height)
+
+	// Field (2) 'Round'
+	dst = ssz.MarshalUint64(dst, m.Round)
+
+	// Offset (3) 'Identifier'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Identifier)
+
+	// Offset (4) 'Data'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Data)
+
+	// Field (3) 'Identifier'
+	if len(m.Identifier) > 56 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, m.Identifier...)
+
+	// Field (4) 'Data'
+	if len(m.Data) > 205580344 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, m.Data...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the MessageSSZ object
+func (m *MessageSSZ) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 32 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o3, o4 uint64
+
+	// Field (0) 'MsgType'
+	m.MsgType = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'Height'
+	m.Height = ssz.UnmarshallUint64(buf[8:16])
+
+	// Field (2) 'Round'
+	m.Round = ssz.UnmarshallUint64(buf[16:24])
+
+	// Offset (3) 'Identifier'
+	if o3 = ssz.ReadOffset(buf[24:28]); o3 > size {
+		return ssz.ErrOffset
+	}
+
+	if o3 < 32 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (4) 'Data'
+	if o4 = ssz.ReadOffset(buf[28:32]); o4 > size || o3 > o4 {
+		return ssz.ErrOffset
+	}
+
+	// Field (3) 'Identifier'
+	{
+		buf = tail[o3:o4]
+		if len(buf) > 56 {
+			return ssz.ErrBytesLength
+		}
+		if cap(m.Identifier) == 0 {
+			m.Identifier = make([]byte, 0, len(buf))
+		}
+		m.Identifier = append(m.Identifier, buf...)
+	}
+
+	// Field (4) 'Data'
+	{
+		buf = tail[o4:]
+		if len(buf) > 205580344 {
+			return ssz.ErrBytesLength
+		}
+		if cap(m.Data) == 0 {
+			m.Data = make([]byte, 0, len(buf))
+		}
+		m.Data = append(m.Data, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the MessageSSZ object
+func (m *MessageSSZ) SizeSSZ() (size int) {
+	size = 32
+
+	// Field (3) 'Identifier'
+	size += len(m.Identifier)
+
+	// Field (4) 'Data'
+	size += len(m.Data)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the MessageSSZ object
+func (m *MessageSSZ) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(m)
+}
+
+// HashTreeRootWith ssz hashes the MessageSSZ object with a hasher
+func (m *MessageSSZ) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'MsgType'
+	hh.PutUint64(m.MsgType)
+
+	// Field (1) 'Height'
+	hh.PutUint64(m.Height)
+
+	// Field (2) 'Round'
+	hh.PutUint64(m.Round)
+
+	// Field (3) 'Identifier'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(m.Identifier))
+		if byteLen > 56 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(m.Identifier)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (56+31)/32)
+	}
+
+	// Field (4) 'Data'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(m.Data))
+		if byteLen > 205580344 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(m.Data)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (205580344+31)/32)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// MarshalSSZ ssz marshals the SignedMessageSSZ object
+func (s *SignedMessageSSZ) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
+}
+
+// MarshalSSZTo ssz marshals the SignedMessageSSZ object to a target array
+func (s *SignedMessageSSZ) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(104)
+
+	// Field (0) 'Signature'
+	if len(s.Signature) != 96 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, s.Signature...)
+
+	// Offset (1) 'Signers'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.Signers) * 8
+
+	// Offset (2) 'Message'
+	dst = ssz.WriteOffset(dst, offset)
+	if s.Message == nil {
+		s.Message = new(MessageSSZ)
+	}
+	offset += s.Message.SizeSSZ()
+
+	// Field (1) 'Signers'
+	if len(s.Signers) > 13 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(s.Signers); ii++ {
+		dst = ssz.MarshalUint64(dst, s.Signers[ii])
+	}
+
+	// Field (2) 'Message'
+	if dst, err = s.Message.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SignedMessageSSZ object
+func (s *SignedMessageSSZ) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 104 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1, o2 uint64
+
+	// Field (0) 'Signature'
+	if cap(s.Signature) == 0 {
+		s.Signature = make([]byte, 0, len(buf[0:96]))
+	}
+	s.Signature = append(s.Signature, buf[0:96]...)
+
+	// Offset (1) 'Signers'
+	if o1 = ssz.ReadOffset(buf[96:100]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 104 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (2) 'Message'
+	if o2 = ssz.ReadOffset(buf[100:104]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Field (1) 'Signers'
+	{
+		buf = tail[o1:o2]
+		num, err := ssz.DivideInt2(len(buf), 8, 13)
+		if err != nil {
+			return err
+		}
+		s.Signers = ssz.ExtendUint64(s.Signers, num)
+		for ii := 0; ii < num; ii++ {
+			s.Signers[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+
+	// Field (2) 'Message'
+	{
+		buf = tail[o2:]
+		if s.Message == nil {
+			s.Message = new(MessageSSZ)
+		}
+		if err = s.Message.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SignedMessageSSZ object
+func (s *SignedMessageSSZ) SizeSSZ() (size int) {
+	size = 104
+
+	// Field (1) 'Signers'
+	size += len(s.Signers) * 8
+
+	// Field (2) 'Message'
+	if s.Message == nil {
+		s.Message = new(MessageSSZ)
+	}
+	size += s.Message.SizeSSZ()
+
+	return
+}
+
+// HashTreeRoot ssz hashes the SignedMessageSSZ object
+func (s *SignedMessageSSZ) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SignedMessageSSZ object with a hasher
+func (s *SignedMessageSSZ) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Signature'
+	if len(s.Signature) != 96 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	hh.PutBytes(s.Signature)
+
+	// Field (1) 'Signers'
+	{
+		if len(s.Signers) > 13 {
+			err = ssz.ErrListTooBig
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range s.Signers {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+		numItems := uint64(len(s.Signers))
+		hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(13, numItems, 8))
+	}
+
+	// Field (2) 'Message'
+	if err = s.Message.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// MarshalSSZ ssz marshals the PrepareJustificationMessageSSZ object
+func (p *PrepareJustificationMessageSSZ) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(p)
+}
+
+// MarshalSSZTo ssz marshals the PrepareJustificationMessageSSZ object to a target array
+func (p *PrepareJustificationMessageSSZ) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(32)
+
+	// Field (0) 'MsgType'
+	dst = ssz.MarshalUint64(dst, p.MsgType)
+
+	// Field (1) 'Height'
+	dst = ssz.MarshalUint64(dst, p.Height)
+
+	// Field (2) 'Round'
+	dst = ssz.MarshalUint64(dst, p.Round)
+
+	// Offset (3) 'Identifier'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(p.Identifier)
+
+	// Offset (4) 'Data'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(p.Data)
+
+	// Field (3) 'Identifier'
+	if len(p.Identifier) > 56 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, p.Identifier...)
+
+	// Field (4) 'Data'
+	if len(p.Data) > 1048580 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, p.Data...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the PrepareJustificationMessageSSZ object
+func (p *PrepareJustificationMessageSSZ) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 32 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o3, o4 uint64
+
+	// Field (0) 'MsgType'
+	p.MsgType = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'Height'
+	p.Height = ssz.UnmarshallUint64(buf[8:16])
+
+	// Field (2) 'Round'
+	p.Round = ssz.UnmarshallUint64(buf[16:24])
+
+	// Offset (3) 'Identifier'
+	if o3 = ssz.ReadOffset(buf[24:28]); o3 > size {
+		return ssz.ErrOffset
+	}
+
+	if o3 < 32 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (4) 'Data'
+	if o4 = ssz.ReadOffset(buf[28:32]); o4 > size || o3 > o4 {
+		return ssz.ErrOffset
+	}
+
+	// Field (3) 'Identifier'
+	{
+		buf = tail[o3:o4]
+		if len(buf) > 56 {
+			return ssz.ErrBytesLength
+		}
+		if cap(p.Identifier) == 0 {
+			p.Identifier = make([]byte, 0, len(buf))
+		}
+		p.Identifier = append(p.Identifier, buf...)
+	}
+
+	// Field (4) 'Data'
+	{
+		buf = tail[o4:]
+		if len(buf) > 1048580 {
+			return ssz.ErrBytesLength
+		}
+		if cap(p.Data) == 0 {
+			p.Data = make([]byte, 0, len(buf))
+		}
+		p.Data = append(p.Data, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the PrepareJustificationMessageSSZ object
+func (p *PrepareJustificationMessageSSZ) SizeSSZ() (size int) {
+	size = 32
+
+	// Field (3) 'Identifier'
+	size += len(p.Identifier)
+
+	// Field (4) 'Data'
+	size += len(p.Data)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the PrepareJustificationMessageSSZ object
+func (p *PrepareJustificationMessageSSZ) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(p)
+}
+
+// HashTreeRootWith ssz hashes the PrepareJustificationMessageSSZ object with a hasher
+func (p *PrepareJustificationMessageSSZ) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'MsgType'
+	hh.PutUint64(p.MsgType)
+
+	// Field (1) 'Height'
+	hh.PutUint64(p.Height)
+
+	// Field (2) 'Round'
+	hh.PutUint64(p.Round)
+
+	// Field (3) 'Identifier'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(p.Identifier))
+		if byteLen > 56 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(p.Identifier)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (56+31)/32)
+	}
+
+	// Field (4) 'Data'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(p.Data))
+		if byteLen > 1048580 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(p.Data)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (1048580+31)/32)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// MarshalSSZ ssz marshals the PrepareJustificationSSZ object
+func (p *PrepareJustificationSSZ) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(p)
+}
+
+// MarshalSSZTo ssz marshals the PrepareJustificationSSZ object to a target array
+func (p *PrepareJustificationSSZ) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(104)
+
+	// Field (0) 'Signature'
+	if len(p.Signature) != 96 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, p.Signature...)
+
+	// Offset (1) 'Signers'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(p.Signers) * 8
+
+	// Offset (2) 'Message'
+	dst = ssz.WriteOffset(dst, offset)
+	if p.Message == nil {
+		p.Message = new(PrepareJustificationMessageSSZ)
+	}
+	offset += p.Message.SizeSSZ()
+
+	// Field (1) 'Signers'
+	if len(p.Signers) > 13 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(p.Signers); ii++ {
+		dst = ssz.MarshalUint64(dst, p.Signers[ii])
+	}
+
+	// Field (2) 'Message'
+	if dst, err = p.Message.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the PrepareJustificationSSZ object
+func (p *PrepareJustificationSSZ) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 104 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1, o2 uint64
+
+	// Field (0) 'Signature'
+	if cap(p.Signature) == 0 {
+		p.Signature = make([]byte, 0, len(buf[0:96]))
+	}
+	p.Signature = append(p.Signature, buf[0:96]...)
+
+	// Offset (1) 'Signers'
+	if o1 = ssz.ReadOffset(buf[96:100]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 104 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (2) 'Message'
+	if o2 = ssz.ReadOffset(buf[100:104]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Field (1) 'Signers'
+	{
+		buf = tail[o1:o2]
+		num, err := ssz.DivideInt2(len(buf), 8, 13)
+		if err != nil {
+			return err
+		}
+		p.Signers = ssz.ExtendUint64(p.Signers, num)
+		for ii := 0; ii < num; ii++ {
+			p.Signers[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+
+	// Field (2) 'Message'
+	{
+		buf = tail[o2:]
+		if p.Message == nil {
+			p.Message = new(PrepareJustificationMessageSSZ)
+		}
+		if err = p.Message.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the PrepareJustificationSSZ object
+func (p *PrepareJustificationSSZ) SizeSSZ() (size int) {
+	size = 104
+
+	// Field (1) 'Signers'
+	size += len(p.Signers) * 8
+
+	// Field (2) 'Message'
+	if p.Message == nil {
+		p.Message = new(PrepareJustificationMessageSSZ)
+	}
+	size += p.Message.SizeSSZ()
+
+	return
+}
+
+// HashTreeRoot ssz hashes the PrepareJustificationSSZ object
+func (p *PrepareJustificationSSZ) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(p)
+}
+
+// HashTreeRootWith ssz hashes the PrepareJustificationSSZ object with a hasher
+func (p *PrepareJustificationSSZ) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Signature'
+	if len(p.Signature) != 96 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	hh.PutBytes(p.Signature)
+
+	// Field (1) 'Signers'
+	{
+		if len(p.Signers) > 13 {
+			err = ssz.ErrListTooBig
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range p.Signers {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+		numItems := uint64(len(p.Signers))
+		hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(13, numItems, 8))
+	}
+
+	// Field (2) 'Message'
+	if err = p.Message.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// MarshalSSZ ssz marshals the RoundChangeJustificationMessageSSZ object
+func (r *RoundChangeJustificationMessageSSZ) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(r)
+}
+
+// MarshalSSZTo ssz marshals the RoundChangeJustificationMessageSSZ object to a target array
+func (r *RoundChangeJustificationMessageSSZ) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(32)
+
+	// Field (0) 'MsgType'
+	dst = ssz.MarshalUint64(dst, r.MsgType)
+
+	// Field (1) 'Height'
+	dst = ssz.MarshalUint64(dst, r.Height)
+
+	// Field (2) 'Round'
+	dst = ssz.MarshalUint64(dst, r.Round)
+
+	// Offset (3) 'Identifier'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(r.Identifier)
+
+	// Offset (4) 'Data'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(r.Data)
+
+	// Field (3) 'Identifier'
+	if len(r.Identifier) > 56 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, r.Identifier...)
+
+	// Field (4) 'Data'
+	if len(r.Data) > 14684032 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, r.Data...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the RoundChangeJustificationMessageSSZ object
+func (r *RoundChangeJustificationMessageSSZ) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 32 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o3, o4 uint64
+
+	// Field (0) 'MsgType'
+	r.MsgType = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'Height'
+	r.Height = ssz.UnmarshallUint64(buf[8:16])
+
+	// Field (2) 'Round'
+	r.Round = ssz.UnmarshallUint64(buf[16:24])
+
+	// Offset (3) 'Identifier'
+	if o3 = ssz.ReadOffset(buf[24:28]); o3 > size {
+		return ssz.ErrOffset
+	}
+
+	if o3 < 32 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (4) 'Data'
+	if o4 = ssz.ReadOffset(buf[28:32]); o4 > size || o3 > o4 {
+		return ssz.ErrOffset
+	}
+
+	// Field (3) 'Identifier'
+	{
+		buf = tail[o3:o4]
+		if len(buf) > 56 {
+			return ssz.ErrBytesLength
+		}
+		if cap(r.Identifier) == 0 {
+			r.Identifier = make([]byte, 0, len(buf))
+		}
+		r.Identifier = append(r.Identifier, buf...)
+	}
+
+	// Field (4) 'Data'
+	{
+		buf = tail[o4:]
+		if len(buf) > 14684032 {
+			return ssz.ErrBytesLength
+		}
+		if cap(r.Data) == 0 {
+			r.Data = make([]byte, 0, len(buf))
+		}
+		r.Data = append(r.Data, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the RoundChangeJustificationMessageSSZ object
+func (r *RoundChangeJustificationMessageSSZ) SizeSSZ() (size int) {
+	size = 32
+
+	// Field (3) 'Identifier'
+	size += len(r.Identifier)
+
+	// Field (4) 'Data'
+	size += len(r.Data)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the RoundChangeJustificationMessageSSZ object
+func (r *RoundChangeJustificationMessageSSZ) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(r)
+}
+
+// HashTreeRootWith ssz hashes the RoundChangeJustificationMessageSSZ object with a hasher
+func (r *RoundChangeJustificationMessageSSZ) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'MsgType'
+	hh.PutUint64(r.MsgType)
+
+	// Field (1) 'Height'
+	hh.PutUint64(r.Height)
+
+	// Field (2) 'Round'
+	hh.PutUint64(r.Round)
+
+	// Field (3) 'Identifier'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(r.Identifier))
+		if byteLen > 56 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(r.Identifier)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (56+31)/32)
+	}
+
+	// Field (4) 'Data'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(r.Data))
+		if byteLen > 14684032 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(r.Data)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (14684032+31)/32)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// MarshalSSZ ssz marshals the RoundChangeJustificationSSZ object
+func (r *RoundChangeJustificationSSZ) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(r)
+}
+
+// MarshalSSZTo ssz marshals the RoundChangeJustificationSSZ object to a target array
+func (r *RoundChangeJustificationSSZ) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(104)
+
+	// Field (0) 'Signature'
+	if len(r.Signature) != 96 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, r.Signature...)
+
+	// Offset (1) 'Signers'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(r.Signers) * 8
+
+	// Offset (2) 'Message'
+	dst = ssz.WriteOffset(dst, offset)
+	if r.Message == nil {
+		r.Message = new(RoundChangeJustificationMessageSSZ)
+	}
+	offset += r.Message.SizeSSZ()
+
+	// Field (1) 'Signers'
+	if len(r.Signers) > 13 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(r.Signers); ii++ {
+		dst = ssz.MarshalUint64(dst, r.Signers[ii])
+	}
+
+	// Field (2) 'Message'
+	if dst, err = r.Message.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the RoundChangeJustificationSSZ object
+func (r *RoundChangeJustificationSSZ) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 104 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1, o2 uint64
+
+	// Field (0) 'Signature'
+	if cap(r.Signature) == 0 {
+		r.Signature = make([]byte, 0, len(buf[0:96]))
+	}
+	r.Signature = append(r.Signature, buf[0:96]...)
+
+	// Offset (1) 'Signers'
+	if o1 = ssz.ReadOffset(buf[96:100]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 104 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (2) 'Message'
+	if o2 = ssz.ReadOffset(buf[100:104]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Field (1) 'Signers'
+	{
+		buf = tail[o1:o2]
+		num, err := ssz.DivideInt2(len(buf), 8, 13)
+		if err != nil {
+			return err
+		}
+		r.Signers = ssz.ExtendUint64(r.Signers, num)
+		for ii := 0; ii < num; ii++ {
+			r.Signers[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+
+	// Field (2) 'Message'
+	{
+		buf = tail[o2:]
+		if r.Message == nil {
+			r.Message = new(RoundChangeJustificationMessageSSZ)
+		}
+		if err = r.Message.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the RoundChangeJustificationSSZ object
+func (r *RoundChangeJustificationSSZ) SizeSSZ() (size int) {
+	size = 104
+
+	// Field (1) 'Signers'
+	size += len(r.Signers) * 8
+
+	// Field (2) 'Message'
+	if r.Message == nil {
+		r.Message = new(RoundChangeJustificationMessageSSZ)
+	}
+	size += r.Message.SizeSSZ()
+
+	return
+}
+
+// HashTreeRoot ssz hashes the RoundChangeJustificationSSZ object
+func (r *RoundChangeJustificationSSZ) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(r)
+}
+
+// HashTreeRootWith ssz hashes the RoundChangeJustificationSSZ object with a hasher
+func (r *RoundChangeJustificationSSZ) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Signature'
+	if len(r.Signature) != 96 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	hh.PutBytes(r.Signature)
+
+	// Field (1) 'Signers'
+	{
+		if len(r.Signers) > 13 {
+			err = ssz.ErrListTooBig
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range r.Signers {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+		numItems := uint64(len(r.Signers))
+		hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(13, numItems, 8))
+	}
+
+	// Field (2) 'Message'
+	if err = r.Message.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// MarshalSSZ ssz marshals the ProposalDataSSZ object
+func (p *ProposalDataSSZ) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(p)
+}
+
+// MarshalSSZTo ssz marshals the ProposalDataSSZ object to a target array
+func (p *ProposalDataSSZ) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(12)
+
+	// Offset (0) 'Data'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(p.Data)
+
+	// Offset (1) 'RoundChangeJustification'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(p.RoundChangeJustification); ii++ {
+		offset += 4
+		offset += p.RoundChangeJustification[ii].SizeSSZ()
+	}
+
+	// Offset (2) 'PrepareJustification'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(p.PrepareJustification); ii++ {
+		offset += 4
+		offset += p.PrepareJustification[ii].SizeSSZ()
+	}
+
+	// Field (0) 'Data'
+	if len(p.Data) > 1048576 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, p.Data...)
+
+	// Field (1) 'RoundChangeJustification'
+	if len(p.RoundChangeJustification) > 13 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	{
+		offset = 4 * len(p.RoundChangeJustification)
+		for ii := 0; ii < len(p.RoundChangeJustification); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += p.RoundChangeJustification[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(p.RoundChangeJustification); ii++ {
+		if dst, err = p.RoundChangeJustification[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (2) 'PrepareJustification'
+	if len(p.PrepareJustification) > 13 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	{
+		offset = 4 * len(p.PrepareJustification)
+		for ii := 0; ii < len(p.PrepareJustification); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += p.PrepareJustification[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(p.PrepareJustification); ii++ {
+		if dst, err = p.PrepareJustification[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the ProposalDataSSZ object
+func (p *ProposalDataSSZ) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 12 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0, o1, o2 uint64
+
+	// Offset (0) 'Data'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 12 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (1) 'RoundChangeJustification'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (2) 'PrepareJustification'
+	if o2 = ssz.ReadOffset(buf[8:12]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Field (0) 'Data'
+	{
+		buf = tail[o0:o1]
+		if len(buf) > 1048576 {
+			return ssz.ErrBytesLength
+		}
+		if cap(p.Data) == 0 {
+			p.Data = make([]byte, 0, len(buf))
+		}
+		p.Data = append(p.Data, buf...)
+	}
+
+	// Field (1) 'RoundChangeJustification'
+	{
+		buf = tail[o1:o2]
+		num, err := ssz.DecodeDynamicLength(buf, 13)
+		if err != nil {
+			return err
+		}
+		p.RoundChangeJustification = make([]*RoundChangeJustificationSSZ, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if p.RoundChangeJustification[indx] == nil {
+				p.RoundChangeJustification[indx] = new(RoundChangeJustificationSSZ)
+			}
+			if err = p.RoundChangeJustification[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Field (2) 'PrepareJustification'
+	{
+		buf = tail[o2:]
+		num, err := ssz.DecodeDynamicLength(buf, 13)
+		if err != nil {
+			return err
+		}
+		p.PrepareJustification = make([]*PrepareJustificationSSZ, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if p.PrepareJustification[indx] == nil {
+				p.PrepareJustification[indx] = new(PrepareJustificationSSZ)
+			}
+			if err = p.PrepareJustification[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the ProposalDataSSZ object
+func (p *ProposalDataSSZ) SizeSSZ() (size int) {
+	size = 12
+
+	// Field (0) 'Data'
+	size += len(p.Data)
+
+	// Field (1) 'RoundChangeJustification'
+	for ii := 0; ii < len(p.RoundChangeJustification); ii++ {
+		size += 4
+		size += p.RoundChangeJustification[ii].SizeSSZ()
+	}
+
+	// Field (2) 'PrepareJustification'
+	for ii := 0; ii < len(p.PrepareJustification); ii++ {
+		size += 4
+		size += p.PrepareJustification[ii].SizeSSZ()
+	}
+
+	return
+}
+
+// HashTreeRoot ssz hashes the ProposalDataSSZ object
+func (p *ProposalDataSSZ) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(p)
+}
+
+// HashTreeRootWith ssz hashes the ProposalDataSSZ object with a hasher
+func (p *ProposalDataSSZ) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Data'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(p.Data))
+		if byteLen > 1048576 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(p.Data)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (1048576+31)/32)
+	}
+
+	// Field (1) 'RoundChangeJustification'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(p.RoundChangeJustification))
+		if num > 13 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range p.RoundChangeJustification {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 13)
+	}
+
+	// Field (2) 'PrepareJustification'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(p.PrepareJustification))
+		if num > 13 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range p.PrepareJustification {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 13)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// MarshalSSZ ssz marshals the PrepareDataSSZ object
+func (p *PrepareDataSSZ) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(p)
+}
+
+// MarshalSSZTo ssz marshals the PrepareDataSSZ object to a target array
+func (p *PrepareDataSSZ) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(4)
+
+	// Offset (0) 'Data'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(p.Data)
+
+	// Field (0) 'Data'
+	if len(p.Data) > 1048576 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, p.Data...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the PrepareDataSSZ object
+func (p *PrepareDataSSZ) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 4 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Data'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 4 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (0) 'Data'
+	{
+		buf = tail[o0:]
+		if len(buf) > 1048576 {
+			return ssz.ErrBytesLength
+		}
+		if cap(p.Data) == 0 {
+			p.Data = make([]byte, 0, len(buf))
+		}
+		p.Data = append(p.Data, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the PrepareDataSSZ object
+func (p *PrepareDataSSZ) SizeSSZ() (size int) {
+	size = 4
+
+	// Field (0) 'Data'
+	size += len(p.Data)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the PrepareDataSSZ object
+func (p *PrepareDataSSZ) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(p)
+}
+
+// HashTreeRootWith ssz hashes the PrepareDataSSZ object with a hasher
+func (p *PrepareDataSSZ) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Data'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(p.Data))
+		if byteLen > 1048576 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(p.Data)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (1048576+31)/32)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// MarshalSSZ ssz marshals the CommitDataSSZ object
+func (c *CommitDataSSZ) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(c)
+}
+
+// MarshalSSZTo ssz marshals the CommitDataSSZ object to a target array
+func (c *CommitDataSSZ) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(4)
+
+	// Offset (0) 'Data'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(c.Data)
+
+	// Field (0) 'Data'
+	if len(c.Data) > 1048576 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, c.Data...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the CommitDataSSZ object
+func (c *CommitDataSSZ) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 4 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Data'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 4 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (0) 'Data'
+	{
+		buf = tail[o0:]
+		if len(buf) > 1048576 {
+			return ssz.ErrBytesLength
+		}
+		if cap(c.Data) == 0 {
+			c.Data = make([]byte, 0, len(buf))
+		}
+		c.Data = append(c.Data, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the CommitDataSSZ object
+func (c *CommitDataSSZ) SizeSSZ() (size int) {
+	size = 4
+
+	// Field (0) 'Data'
+	size += len(c.Data)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the CommitDataSSZ object
+func (c *CommitDataSSZ) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(c)
+}
+
+// HashTreeRootWith ssz hashes the CommitDataSSZ object with a hasher
+func (c *CommitDataSSZ) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Data'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(c.Data))
+		if byteLen > 1048576 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(c.Data)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (1048576+31)/32)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// MarshalSSZ ssz marshals the RoundChangeDataSSZ object
+func (r *RoundChangeDataSSZ) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(r)
+}
+
+// MarshalSSZTo ssz marshals the RoundChangeDataSSZ object to a target array
+func (r *RoundChangeDataSSZ) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(16)
+
+	// Offset (0) 'PreparedValue'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(r.PreparedValue)
+
+	// Field (1) 'PreparedRound'
+	dst = ssz.MarshalUint64(dst, r.PreparedRound)
+
+	// Offset (2) 'RoundChangeJustification'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(r.RoundChangeJustification); ii++ {
+		offset += 4
+		offset += r.RoundChangeJustification[ii].SizeSSZ()
+	}
+
+	// Field (0) 'PreparedValue'
+	if len(r.PreparedValue) > 1048576 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, r.PreparedValue...)
+
+	// Field (2) 'RoundChangeJustification'
+	if len(r.RoundChangeJustification) > 13 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	{
+		offset = 4 * len(r.RoundChangeJustification)
+		for ii := 0; ii < len(r.RoundChangeJustification); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += r.RoundChangeJustification[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(r.RoundChangeJustification); ii++ {
+		if dst, err = r.RoundChangeJustification[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the RoundChangeDataSSZ object
+func (r *RoundChangeDataSSZ) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 16 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0, o2 uint64
+
+	// Offset (0) 'PreparedValue'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 16 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (1) 'PreparedRound'
+	r.PreparedRound = ssz.UnmarshallUint64(buf[4:12])
+
+	// Offset (2) 'RoundChangeJustification'
+	if o2 = ssz.ReadOffset(buf[12:16]); o2 > size || o0 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Field (0) 'PreparedValue'
+	{
+		buf = tail[o0:o2]
+		if len(buf) > 1048576 {
+			return ssz.ErrBytesLength
+		}
+		if cap(r.PreparedValue) == 0 {
+			r.PreparedValue = make([]byte, 0, len(buf))
+		}
+		r.PreparedValue = append(r.PreparedValue, buf...)
+	}
+
+	// Field (2) 'RoundChangeJustification'
+	{
+		buf = tail[o2:]
+		num, err := ssz.DecodeDynamicLength(buf, 13)
+		if err != nil {
+			return err
+		}
+		r.RoundChangeJustification = make([]*PrepareJustificationSSZ, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if r.RoundChangeJustification[indx] == nil {
+				r.RoundChangeJustification[indx] = new(PrepareJustificationSSZ)
+			}
+			if err = r.RoundChangeJustification[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the RoundChangeDataSSZ object
+func (r *RoundChangeDataSSZ) SizeSSZ() (size int) {
+	size = 16
+
+	// Field (0) 'PreparedValue'
+	size += len(r.PreparedValue)
+
+	// Field (2) 'RoundChangeJustification'
+	for ii := 0; ii < len(r.RoundChangeJustification); ii++ {
+		size += 4
+		size += r.RoundChangeJustification[ii].SizeSSZ()
+	}
+
+	return
+}
+
+// HashTreeRoot ssz hashes the RoundChangeDataSSZ object
+func (r *RoundChangeDataSSZ) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(r)
+}
+
+// HashTreeRootWith ssz hashes the RoundChangeDataSSZ object with a hasher
+func (r *RoundChangeDataSSZ) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'PreparedValue'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(r.PreparedValue))
+		if byteLen > 1048576 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.PutBytes(r.PreparedValue)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (1048576+31)/32)
+	}
+
+	// Field (1) 'PreparedRound'
+	hh.PutUint64(r.PreparedRound)
+
+	// Field (2) 'RoundChangeJustification'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(r.RoundChangeJustification))
+		if num > 13 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range r.RoundChangeJustification {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 13)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
@@ -26,6 +26,38 @@ type IConfig interface {
 	GetStorage() Storage
 	// GetTimer returns round timer
 	GetTimer() Timer
+	// GetRoundTimeoutPolicy returns the round timeout policy, nil for DefaultRoundTimeoutPolicy
+	GetRoundTimeoutPolicy() RoundTimeoutPolicy
+	// GetControllerStorage returns a controller snapshot storage, nil if controller snapshots are disabled
+	GetControllerStorage() ControllerStorage
+	// GetHistoricalInstanceCapacity returns the number of instances a controller stores, HistoricalInstanceCapacity if below 1
+	GetHistoricalInstanceCapacity() int
+	// GetInstanceEvictionF returns a func called with every instance evicted from a controller's stored instances, nil if not set
+	GetInstanceEvictionF() InstanceEvictionF
+	// GetMaxConcurrentInstances returns the max number of instances a controller can run concurrently, 0 or 1 for sequential instances
+	GetMaxConcurrentInstances() int
+	// GetEquivocationF returns a func called with every equivocation evidence, nil if not set
+	GetEquivocationF() EquivocationF
+	// GetLateCommitWindow returns the number of heights (up to the controller's height) for which late commits are aggregated into an improved decided msg, 0 if disabled
+	GetLateCommitWindow() Height
+	// GetObserver returns an Observer for instance lifecycle events, nil if not set
+	GetObserver() Observer
+	// GetLogger returns a Logger for non-fatal errors, never nil
+	GetLogger() Logger
+	// GetInstanceTimerF returns a func creating a round timer per instance, nil to share GetTimer between all instances
+	GetInstanceTimerF() InstanceTimerF
+	// GetAsyncValueCheck returns true if proposed values are checked before (and not blocking) an instance's msg processing
+	GetAsyncValueCheck() bool
+	// GetInvariantViolationF returns a func called with every invariant violation, nil if invariants are not checked
+	GetInvariantViolationF() InvariantViolationF
+	// GetValueProvider returns a ValueProvider for values proposed after round changes, nil to propose the instance's start value
+	GetValueProvider() ValueProvider
+	// GetRateLimit returns the per signer msg rate limit of a controller, nil if not rate limited
+	GetRateLimit() *RateLimit
+	// GetMisbehaviorF returns a func called with every misbehavior a controller detects, nil if not set
+	GetMisbehaviorF() MisbehaviorF
+	// GetEncodingVersion returns the version with which msgs and msg data are encoded and signed
+	GetEncodingVersion() EncodingVersion
 }
 
 type Config struct {
@@ -37,6 +69,41 @@ type Config struct {
 	Storage     Storage
 	Network     Network
 	Timer       Timer
+	// RoundTimeoutPolicy is optional, DefaultRoundTimeoutPolicy is used if not set
+	RoundTimeoutPolicy RoundTimeoutPolicy
+	// ControllerStorage is optional, when set the controller checkpoints its state on every state transition and broadcasts msgs only once checkpointed
+	ControllerStorage ControllerStorage
+	// HistoricalInstanceCapacity is optional, the number of instances a controller stores (HistoricalInstanceCapacity if not set)
+	HistoricalInstanceCapacity int
+	// InstanceEvictionF is optional, called with every instance evicted from a controller's stored instances (e.g. SaveDecidedOnEviction)
+	InstanceEvictionF InstanceEvictionF
+	// MaxConcurrentInstances is optional, when above 1 the controller can start a new instance before previous ones decided (capped at the historical instance capacity).
+	// Requires InstanceTimerF, instances run sequentially without it
+	MaxConcurrentInstances int
+	// InstanceTimerF is optional, when set every instance gets its own round timer (required for independent timeouts of concurrent instances)
+	InstanceTimerF InstanceTimerF
+	// EquivocationF is optional, called with every equivocation evidence (an operator signing conflicting msgs)
+	EquivocationF EquivocationF
+	// LateCommitWindow is optional, when set commits arriving after an instance decided are aggregated and an improved decided msg (more signers) is saved and broadcasted.
+	// Only instances within LateCommitWindow heights up to the controller's height aggregate late commits.
+	LateCommitWindow Height
+	// Observer is optional, receives instance lifecycle events (e.g. for metrics and logs)
+	Observer Observer
+	// Logger is optional, non-fatal errors are discarded if not set
+	Logger Logger
+	// AsyncValueCheck is optional, when set the values of proposal and round change msgs are checked (once their height, signature and leader are validated) before the instance processes the msg.
+	// Expensive value checks (e.g. slashing protection lookups) then don't block the instance from processing other msgs concurrently
+	AsyncValueCheck bool
+	// InvariantViolationF is optional, when set every instance state transition is checked for safety invariant violations (see Invariant) which are passed to it
+	InvariantViolationF InvariantViolationF
+	// ValueProvider is optional, when set a leader proposing after a round change without a prepared value proposes a fresh (value checked) value from it instead of the instance's start value
+	ValueProvider ValueProvider
+	// RateLimit is optional, when set a controller rejects msgs exceeding it before verifying their signature
+	RateLimit *RateLimit
+	// MisbehaviorF is optional, when set a controller verifies msgs before processing them and passes every misbehavior (invalid or rate limited msg) to it
+	MisbehaviorF MisbehaviorF
+	// EncodingVersion is optional, the version with which created msgs (and their data) are encoded, signed and broadcasted and with which msg signatures are verified (JSONEncodingVersion if not set)
+	EncodingVersion EncodingVersion
 }
 
 // GetSigner returns a Signer instance
@@ -79,6 +146,89 @@ func (c *Config) GetTimer() Timer {
 	return c.Timer
 }
 
+// GetRoundTimeoutPolicy returns the round timeout policy, nil for DefaultRoundTimeoutPolicy
+func (c *Config) GetRoundTimeoutPolicy() RoundTimeoutPolicy {
+	return c.RoundTimeoutPolicy
+}
+
+// GetControllerStorage returns a controller snapshot storage, nil if controller snapshots are disabled
+func (c *Config) GetControllerStorage() ControllerStorage {
+	return c.ControllerStorage
+}
+
+// GetHistoricalInstanceCapacity returns the number of instances a controller stores, HistoricalInstanceCapacity if below 1
+func (c *Config) GetHistoricalInstanceCapacity() int {
+	return c.HistoricalInstanceCapacity
+}
+
+// GetInstanceEvictionF returns a func called with every instance evicted from a controller's stored instances, nil if not set
+func (c *Config) GetInstanceEvictionF() InstanceEvictionF {
+	return c.InstanceEvictionF
+}
+
+// GetMaxConcurrentInstances returns the max number of instances a controller can run concurrently, 0 or 1 for sequential instances
+func (c *Config) GetMaxConcurrentInstances() int {
+	return c.MaxConcurrentInstances
+}
+
+// GetInstanceTimerF returns a func creating a round timer per instance, nil to share GetTimer between all instances
+func (c *Config) GetInstanceTimerF() InstanceTimerF {
+	return c.InstanceTimerF
+}
+
+// GetEquivocationF returns a func called with every equivocation evidence, nil if not set
+func (c *Config) GetEquivocationF() EquivocationF {
+	return c.EquivocationF
+}
+
+// GetLateCommitWindow returns the number of heights (up to the controller's height) for which late commits are aggregated into an improved decided msg, 0 if disabled
+func (c *Config) GetLateCommitWindow() Height {
+	return c.LateCommitWindow
+}
+
+// GetObserver returns an Observer for instance lifecycle events, nil if not set
+func (c *Config) GetObserver() Observer {
+	return c.Observer
+}
+
+// GetLogger returns a Logger for non-fatal errors, a NopLogger if not set
+func (c *Config) GetLogger() Logger {
+	if c.Logger == nil {
+		return NopLogger{}
+	}
+	return c.Logger
+}
+
+// GetAsyncValueCheck returns true if proposed values are checked before (and not blocking) an instance's msg processing
+func (c *Config) GetAsyncValueCheck() bool {
+	return c.AsyncValueCheck
+}
+
+// GetInvariantViolationF returns a func called with every invariant violation, nil if invariants are not checked
+func (c *Config) GetInvariantViolationF() InvariantViolationF {
+	return c.InvariantViolationF
+}
+
+// GetValueProvider returns a ValueProvider for values proposed after round changes, nil to propose the instance's start value
+func (c *Config) GetValueProvider() ValueProvider {
+	return c.ValueProvider
+}
+
+// GetRateLimit returns the per signer msg rate limit of a controller, nil if not rate limited
+func (c *Config) GetRateLimit() *RateLimit {
+	return c.RateLimit
+}
+
+// GetMisbehaviorF returns a func called with every misbehavior a controller detects, nil if not set
+func (c *Config) GetMisbehaviorF() MisbehaviorF {
+	return c.MisbehaviorF
+}
+
+// GetEncodingVersion returns the version with which msgs and msg data are encoded and signed
+func (c *Config) GetEncodingVersion() EncodingVersion {
+	return c.EncodingVersion
+}
+
 type State struct {
 	Share                           *types.Share
 	ID                              []byte // instance Identifier
@@ -89,6 +239,8 @@ type State struct {
 	ProposalAcceptedForCurrentRound *SignedMessage
 	Decided                         bool
 	DecidedValue                    []byte
+	// Stopped is true once the instance was stopped (see Instance.Stop), omitted from the encoded state (and root) if false
+	Stopped bool `json:",omitempty"`
 
 	ProposeContainer     *MsgContainer
 	PrepareContainer     *MsgContainer
@@ -0,0 +1,85 @@
+package qbft_test
+
+import (
+	"context"
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestInstance_Stop(t *testing.T) {
+	clock := testingutils.NewTestingClock()
+	inst := testingutils.BaseInstance()
+	config := inst.GetConfig().(*qbft.Config)
+	config.Timer = qbft.NewRoundTimerWithClock(context.Background(), clock, func(round qbft.Round) {
+		require.NoError(t, inst.ProcessTimeout(round))
+	})
+	events := make([]qbft.EventType, 0)
+	config.Observer = qbft.ObserverF(func(event *qbft.Event) {
+		events = append(events, event.Type)
+	})
+	net := config.GetNetwork().(*testingutils.TestingNetwork)
+
+	inst.Start([]byte{1, 2, 3, 4}, qbft.FirstHeight)
+	rootBefore, err := inst.GetRoot()
+	require.NoError(t, err)
+	broadcasted := len(net.BroadcastedMsgs)
+
+	inst.Stop()
+	inst.Stop() // no-op
+	require.True(t, inst.IsStopped())
+	require.EqualValues(t, []qbft.EventType{qbft.InstanceStartedEvent, qbft.InstanceStoppedEvent}, events)
+
+	rootAfter, err := inst.GetRoot()
+	require.NoError(t, err)
+	require.NotEqualValues(t, rootBefore, rootAfter)
+
+	// timer stopped
+	clock.Advance(time.Minute)
+	require.EqualValues(t, qbft.FirstRound, inst.State.Round)
+
+	// msgs rejected
+	_, _, _, err = inst.ProcessMsg(testingutils.SignQBFTMsg(testingutils.Testing4SharesSet().Shares[1], 1, &qbft.Message{
+		MsgType:    qbft.ProposalMsgType,
+		Height:     qbft.FirstHeight,
+		Round:      qbft.FirstRound,
+		Identifier: []byte{1, 2, 3, 4},
+		Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 4}, nil, nil),
+	}))
+	require.True(t, errors.Is(err, qbft.ErrInstanceStopped))
+	require.Nil(t, inst.State.ProposalAcceptedForCurrentRound)
+
+	// timeouts ignored
+	require.NoError(t, inst.ProcessTimeout(qbft.FirstRound))
+	require.Len(t, net.BroadcastedMsgs, broadcasted)
+}
+
+func TestController_StopInstance(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	config := testingutils.TestingConfig(ks)
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+
+	require.EqualError(t, c.StopInstance(qbft.FirstHeight), "instance not found")
+
+	require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+	require.EqualError(t, c.StartNewInstance([]byte{1, 2, 3, 4}), "can't start new QBFT instance: previous instance hasn't Decided")
+
+	require.NoError(t, c.StopInstance(qbft.FirstHeight))
+	_, err := c.ProcessMsg(testingutils.SignQBFTMsg(ks.Shares[2], 2, &qbft.Message{
+		MsgType:    qbft.RoundChangeMsgType,
+		Height:     qbft.FirstHeight,
+		Round:      2,
+		Identifier: identifier[:],
+		Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+	}))
+	require.EqualError(t, err, "could not process msg: instance stopped")
+
+	// a stopped instance doesn't prevent the next instance from starting
+	require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+	require.EqualValues(t, 1, c.Height)
+}
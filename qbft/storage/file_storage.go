@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	highestDecidedFileName     = "highest.json"
+	controllerSnapshotFileName = "controller.json"
+	decidedDirName             = "decided"
+	jsonFileExt                = ".json"
+	equivocationsDirName       = "equivocations"
+)
+
+// FileStorage is an on-disk reference implementation of qbft.HistoricalStorage, qbft.ControllerStorage and qbft.EquivocationStorage.
+// Every identifier gets its own directory (hex encoded) holding the highest decided msg, the controller snapshot, a decided directory with a file per height
+// and an equivocations directory with a file per evidence:
+//
+//	<dir>/<identifier>/highest.json
+//	<dir>/<identifier>/controller.json
+//	<dir>/<identifier>/decided/<height>.json
+//	<dir>/<identifier>/equivocations/<signer>_<height>_<round>_<msg type>.json
+type FileStorage struct {
+	dir  string
+	lock sync.RWMutex
+}
+
+// NewFileStorage returns a new FileStorage rooted at dir, creating it if needed
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "could not create storage dir")
+	}
+	return &FileStorage{
+		dir: dir,
+	}, nil
+}
+
+// SaveHighestDecided saves (and potentially overrides) the highest Decided for a specific instance
+func (s *FileStorage) SaveHighestDecided(signedMsg *qbft.SignedMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.write(s.identifierDir(signedMsg.Message.Identifier), highestDecidedFileName, signedMsg)
+}
+
+// GetHighestDecided returns highest decided if found, nil if didn't
+func (s *FileStorage) GetHighestDecided(identifier []byte) (*qbft.SignedMessage, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.read(filepath.Join(s.identifierDir(identifier), highestDecidedFileName))
+}
+
+// SaveDecided saves (and potentially overrides) the Decided msg for its identifier and height
+func (s *FileStorage) SaveDecided(signedMsg *qbft.SignedMessage) error {
+	if signedMsg.Message.Height < qbft.FirstHeight {
+		return errors.New("decided height invalid")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.write(s.decidedDir(signedMsg.Message.Identifier), decidedFileName(signedMsg.Message.Height), signedMsg)
+}
+
+// GetDecided returns all Decided msgs found for identifier between from and to heights (inclusive), sorted by height
+func (s *FileStorage) GetDecided(identifier []byte, from qbft.Height, to qbft.Height) ([]*qbft.SignedMessage, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ret := make([]*qbft.SignedMessage, 0)
+	if from > to {
+		return ret, nil
+	}
+
+	dir := s.decidedDir(identifier)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ret, nil
+		}
+		return nil, errors.Wrap(err, "could not read decided dir")
+	}
+
+	heights := make([]qbft.Height, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), jsonFileExt) {
+			continue
+		}
+		h, err := strconv.ParseInt(strings.TrimSuffix(entry.Name(), jsonFileExt), 10, 64)
+		if err != nil {
+			continue // not a decided file
+		}
+		if qbft.Height(h) >= from && qbft.Height(h) <= to {
+			heights = append(heights, qbft.Height(h))
+		}
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	for _, h := range heights {
+		msg, err := s.read(filepath.Join(dir, decidedFileName(h)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read decided for height %d", h)
+		}
+		ret = append(ret, msg)
+	}
+	return ret, nil
+}
+
+// SaveControllerSnapshot saves (and overrides) the encoded controller snapshot for a specific controller identifier
+func (s *FileStorage) SaveControllerSnapshot(identifier []byte, data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return writeFile(s.identifierDir(identifier), controllerSnapshotFileName, data)
+}
+
+// GetControllerSnapshot returns the encoded controller snapshot if found, nil if didn't
+func (s *FileStorage) GetControllerSnapshot(identifier []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	byts, err := ioutil.ReadFile(filepath.Join(s.identifierDir(identifier), controllerSnapshotFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "could not read controller snapshot")
+	}
+	return byts, nil
+}
+
+// SaveEquivocationEvidence saves (and potentially overrides) the evidence for its signer, height, round and msg type
+func (s *FileStorage) SaveEquivocationEvidence(evidence *qbft.EquivocationEvidence) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	byts, err := evidence.Encode()
+	if err != nil {
+		return errors.Wrap(err, "could not encode equivocation evidence")
+	}
+	msg := evidence.FirstMsg.Message
+	fileName := fmt.Sprintf("%d_%d_%d_%d%s", evidence.GetSigner(), msg.Height, msg.Round, msg.MsgType, jsonFileExt)
+	return writeFile(filepath.Join(s.identifierDir(msg.Identifier), equivocationsDirName), fileName, byts)
+}
+
+// GetEquivocationEvidence returns all evidence saved for identifier
+func (s *FileStorage) GetEquivocationEvidence(identifier []byte) ([]*qbft.EquivocationEvidence, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ret := make([]*qbft.EquivocationEvidence, 0)
+	dir := filepath.Join(s.identifierDir(identifier), equivocationsDirName)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ret, nil
+		}
+		return nil, errors.Wrap(err, "could not read equivocations dir")
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), jsonFileExt) {
+			continue
+		}
+		byts, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read equivocation evidence")
+		}
+		evidence := &qbft.EquivocationEvidence{}
+		if err := evidence.Decode(byts); err != nil {
+			return nil, errors.Wrap(err, "could not decode equivocation evidence")
+		}
+		ret = append(ret, evidence)
+	}
+	return ret, nil
+}
+
+func (s *FileStorage) identifierDir(identifier []byte) string {
+	return filepath.Join(s.dir, hex.EncodeToString(identifier))
+}
+
+func (s *FileStorage) decidedDir(identifier []byte) string {
+	return filepath.Join(s.identifierDir(identifier), decidedDirName)
+}
+
+func decidedFileName(height qbft.Height) string {
+	return fmt.Sprintf("%d%s", height, jsonFileExt)
+}
+
+// write encodes and atomically writes the msg to dir/fileName
+func (s *FileStorage) write(dir string, fileName string, signedMsg *qbft.SignedMessage) error {
+	byts, err := signedMsg.Encode()
+	if err != nil {
+		return errors.Wrap(err, "could not encode decided msg")
+	}
+	return writeFile(dir, fileName, byts)
+}
+
+// writeFile writes data to a temp file which is then renamed to dir/fileName, so a crash never leaves a partially written file
+func writeFile(dir string, fileName string, data []byte) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrap(err, "could not create dir")
+	}
+
+	tmp, err := ioutil.TempFile(dir, fileName+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "could not create temp file")
+	}
+	defer os.Remove(tmp.Name()) // nolint
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() // nolint
+		return errors.Wrap(err, "could not write temp file")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close() // nolint
+		return errors.Wrap(err, "could not sync temp file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "could not close temp file")
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, fileName)); err != nil {
+		return errors.Wrap(err, "could not rename temp file")
+	}
+	return nil
+}
+
+// read returns the decoded msg at path, nil if not found
+func (s *FileStorage) read(path string) (*qbft.SignedMessage, error) {
+	byts, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "could not read file")
+	}
+
+	ret := &qbft.SignedMessage{}
+	if err := ret.Decode(byts); err != nil {
+		return nil, errors.Wrap(err, "could not decode decided msg")
+	}
+	return ret, nil
+}
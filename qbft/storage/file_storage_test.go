@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func decidedMsgForHeight(identifier []byte, height qbft.Height) *qbft.SignedMessage {
+	return qbft.SignMsg(qbft.TestingSK, 1, &qbft.Message{
+		MsgType:    qbft.CommitMsgType,
+		Height:     height,
+		Round:      qbft.FirstRound,
+		Identifier: identifier,
+		Data:       []byte{1, 2, 3, 4},
+	})
+}
+
+func TestFileStorage_HighestDecided(t *testing.T) {
+	s, err := NewFileStorage(t.TempDir())
+	require.NoError(t, err)
+
+	identifier := []byte{1, 2, 3, 4}
+
+	t.Run("not found", func(t *testing.T) {
+		msg, err := s.GetHighestDecided(identifier)
+		require.NoError(t, err)
+		require.Nil(t, msg)
+	})
+
+	t.Run("save and override", func(t *testing.T) {
+		require.NoError(t, s.SaveHighestDecided(decidedMsgForHeight(identifier, 1)))
+		require.NoError(t, s.SaveHighestDecided(decidedMsgForHeight(identifier, 2)))
+
+		msg, err := s.GetHighestDecided(identifier)
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+		require.EqualValues(t, 2, msg.Message.Height)
+	})
+}
+
+func TestFileStorage_DecidedHistory(t *testing.T) {
+	s, err := NewFileStorage(t.TempDir())
+	require.NoError(t, err)
+
+	identifier := []byte{1, 2, 3, 4}
+	for _, h := range []qbft.Height{0, 1, 2, 5, 10, 11} {
+		require.NoError(t, s.SaveDecided(decidedMsgForHeight(identifier, h)))
+	}
+	// different identifier
+	require.NoError(t, s.SaveDecided(decidedMsgForHeight([]byte{1, 2, 3, 5}, 3)))
+
+	heights := func(msgs []*qbft.SignedMessage) []qbft.Height {
+		ret := make([]qbft.Height, 0)
+		for _, msg := range msgs {
+			ret = append(ret, msg.Message.Height)
+		}
+		return ret
+	}
+
+	t.Run("full range", func(t *testing.T) {
+		msgs, err := s.GetDecided(identifier, 0, 100)
+		require.NoError(t, err)
+		require.EqualValues(t, []qbft.Height{0, 1, 2, 5, 10, 11}, heights(msgs))
+	})
+
+	t.Run("sub range", func(t *testing.T) {
+		msgs, err := s.GetDecided(identifier, 2, 10)
+		require.NoError(t, err)
+		require.EqualValues(t, []qbft.Height{2, 5, 10}, heights(msgs))
+	})
+
+	t.Run("single height", func(t *testing.T) {
+		msgs, err := s.GetDecided(identifier, 5, 5)
+		require.NoError(t, err)
+		require.EqualValues(t, []qbft.Height{5}, heights(msgs))
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		msgs, err := s.GetDecided(identifier, 6, 9)
+		require.NoError(t, err)
+		require.Len(t, msgs, 0)
+	})
+
+	t.Run("unknown identifier", func(t *testing.T) {
+		msgs, err := s.GetDecided([]byte{1, 1, 1, 1}, 0, 100)
+		require.NoError(t, err)
+		require.Len(t, msgs, 0)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		msg := decidedMsgForHeight(identifier, 5)
+		msg.Signers = []types.OperatorID{1, 2, 3}
+		require.NoError(t, s.SaveDecided(msg))
+
+		msgs, err := s.GetDecided(identifier, 5, 5)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.EqualValues(t, []types.OperatorID{1, 2, 3}, msgs[0].Signers)
+	})
+
+	t.Run("negative height", func(t *testing.T) {
+		require.EqualError(t, s.SaveDecided(decidedMsgForHeight(identifier, -1)), "decided height invalid")
+	})
+}
+
+func TestFileStorage_ControllerSnapshot(t *testing.T) {
+	s, err := NewFileStorage(t.TempDir())
+	require.NoError(t, err)
+
+	identifier := []byte{1, 2, 3, 4}
+
+	byts, err := s.GetControllerSnapshot(identifier)
+	require.NoError(t, err)
+	require.Nil(t, byts)
+
+	require.NoError(t, s.SaveControllerSnapshot(identifier, []byte{1, 2, 3}))
+	require.NoError(t, s.SaveControllerSnapshot(identifier, []byte{4, 5, 6}))
+
+	byts, err = s.GetControllerSnapshot(identifier)
+	require.NoError(t, err)
+	require.EqualValues(t, []byte{4, 5, 6}, byts)
+}
+
+func TestFileStorage_EquivocationEvidence(t *testing.T) {
+	s, err := NewFileStorage(t.TempDir())
+	require.NoError(t, err)
+
+	identifier := []byte{1, 2, 3, 4}
+
+	evidence, err := s.GetEquivocationEvidence(identifier)
+	require.NoError(t, err)
+	require.Len(t, evidence, 0)
+
+	conflicting := decidedMsgForHeight(identifier, 2)
+	conflicting.Message.Data = []byte{5, 6, 7, 8}
+	saved := &qbft.EquivocationEvidence{
+		FirstMsg:  decidedMsgForHeight(identifier, 2),
+		SecondMsg: conflicting,
+		Reporter:  2,
+		Signature: make([]byte, 96),
+	}
+	require.NoError(t, s.SaveEquivocationEvidence(saved))
+	// override
+	require.NoError(t, s.SaveEquivocationEvidence(saved))
+
+	evidence, err = s.GetEquivocationEvidence(identifier)
+	require.NoError(t, err)
+	require.Len(t, evidence, 1)
+	require.EqualValues(t, types.OperatorID(1), evidence[0].GetSigner())
+	require.EqualValues(t, []byte{5, 6, 7, 8}, evidence[0].SecondMsg.Message.Data)
+}
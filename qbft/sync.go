@@ -0,0 +1,68 @@
+package qbft
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ProcessSyncHighestDecided processes a SyncHighestDecided response.
+// A valid decided msg for a height higher than the controller's will fast-forward the controller to that height.
+// A nil msg (no decided found by peers) is ignored.
+// Returns the decided msg if it decided an instance, nil otherwise
+func (c *Controller) ProcessSyncHighestDecided(msg *SignedMessage) (*SignedMessage, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if msg == nil {
+		return nil, nil
+	}
+
+	if err := c.baseMsgValidation(msg); err != nil {
+		return nil, errors.Wrap(err, "invalid synced decided msg")
+	}
+	if err := validateDecided(c.GetConfig(), msg, c.Share); err != nil {
+		return nil, errors.Wrap(err, "invalid synced decided msg")
+	}
+
+	decided, err := c.uponValidDecided(msg)
+	if checkpointErr := c.checkpoint(); checkpointErr != nil {
+		// no need to fail processing the msg if failed to checkpoint
+		c.logError(checkpointErr, msg)
+	}
+	return decided, err
+}
+
+// ProcessSyncHighestRoundChange processes a SyncHighestRoundChange response (the highest round change msg per signer) for the running instance.
+// All valid round change msgs are fed into the running instance at once (see Instance.ProcessSyncedRoundChanges), invalid msgs are skipped.
+// Returns an error if no instance is running or if any of the msgs failed processing
+func (c *Controller) ProcessSyncHighestRoundChange(msgs []*SignedMessage) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	inst := c.InstanceForHeight(c.Height)
+	if inst == nil {
+		return errors.New("instance not found")
+	}
+	if decided, _ := inst.IsDecided(); decided {
+		return nil // no need to catch up on round changes
+	}
+
+	var lastErr error
+	validMsgs := make([]*SignedMessage, 0)
+	for _, msg := range msgs {
+		if err := c.baseMsgValidation(msg); err != nil {
+			lastErr = errors.Wrap(err, "invalid synced round change msg")
+			continue
+		}
+		validMsgs = append(validMsgs, msg)
+	}
+
+	if err := inst.ProcessSyncedRoundChanges(validMsgs); err != nil {
+		lastErr = err
+	}
+
+	if err := c.checkpoint(); err != nil {
+		// no need to fail processing the msgs if failed to checkpoint
+		c.logError(err, nil)
+	}
+	return lastErr
+}
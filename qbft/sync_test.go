@@ -0,0 +1,72 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestController_ProcessSyncHighestRoundChange(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	setup := func() (*qbft.Controller, *testingutils.TestingNetwork) {
+		config := testingutils.TestingConfig(ks)
+		identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+		c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		return c, config.GetNetwork().(*testingutils.TestingNetwork)
+	}
+	rc := func(c *qbft.Controller, id types.OperatorID, round qbft.Round) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+			MsgType:    qbft.RoundChangeMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      round,
+			Identifier: c.Identifier,
+			Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+		})
+	}
+	// broadcastedTypes returns the types of the msgs broadcasted after the first skipped msgs
+	broadcastedTypes := func(net *testingutils.TestingNetwork, skip int) []qbft.MessageType {
+		ret := make([]qbft.MessageType, 0)
+		for _, ssvMsg := range net.BroadcastedMsgs[skip:] {
+			msg := &qbft.SignedMessage{}
+			require.NoError(t, msg.Decode(ssvMsg.Data))
+			ret = append(ret, msg.Message.MsgType)
+		}
+		return ret
+	}
+
+	t.Run("jump and propose", func(t *testing.T) {
+		c, net := setup()
+		skip := len(net.BroadcastedMsgs)
+		require.NoError(t, c.ProcessSyncHighestRoundChange([]*qbft.SignedMessage{rc(c, 2, 5), rc(c, 3, 5), rc(c, 4, 5)}))
+		require.EqualValues(t, 5, c.InstanceForHeight(qbft.FirstHeight).State.Round)
+		require.EqualValues(t, []qbft.MessageType{qbft.RoundChangeMsgType, qbft.ProposalMsgType}, broadcastedTypes(net, skip))
+	})
+
+	t.Run("jump to highest f+1 round", func(t *testing.T) {
+		c, net := setup()
+		skip := len(net.BroadcastedMsgs)
+		require.NoError(t, c.ProcessSyncHighestRoundChange([]*qbft.SignedMessage{rc(c, 2, 5), rc(c, 3, 9), rc(c, 4, 7)}))
+		require.EqualValues(t, 7, c.InstanceForHeight(qbft.FirstHeight).State.Round)
+		require.EqualValues(t, []qbft.MessageType{qbft.RoundChangeMsgType}, broadcastedTypes(net, skip))
+	})
+
+	t.Run("no partial quorum", func(t *testing.T) {
+		c, net := setup()
+		skip := len(net.BroadcastedMsgs)
+		require.NoError(t, c.ProcessSyncHighestRoundChange([]*qbft.SignedMessage{rc(c, 2, 5)}))
+		require.EqualValues(t, qbft.FirstRound, c.InstanceForHeight(qbft.FirstHeight).State.Round)
+		require.Len(t, broadcastedTypes(net, skip), 0)
+	})
+
+	t.Run("invalid msg skipped", func(t *testing.T) {
+		c, _ := setup()
+		invalid := rc(c, 4, 5)
+		invalid.Signature = rc(c, 3, 6).Signature
+		require.EqualError(t, c.ProcessSyncHighestRoundChange([]*qbft.SignedMessage{rc(c, 2, 5), invalid, rc(c, 3, 5)}),
+			"invalid synced round change msg: round change msg signature invalid: failed to verify signature")
+		require.EqualValues(t, 5, c.InstanceForHeight(qbft.FirstHeight).State.Round)
+	})
+}
@@ -93,10 +93,8 @@ var testingInstanceStruct = &Instance{
 	},
 }
 var testingControllerStruct = &Controller{
-	Identifier: []byte{1, 2, 3, 4},
-	Height:     Height(1),
-	Share:      testingShare,
-	StoredInstances: [HistoricalInstanceCapacity]*Instance{
-		testingInstanceStruct,
-	},
+	Identifier:      []byte{1, 2, 3, 4},
+	Height:          Height(1),
+	Share:           testingShare,
+	StoredInstances: NewInstanceContainer(HistoricalInstanceCapacity, testingInstanceStruct),
 }
@@ -2,24 +2,126 @@ package qbft
 
 import (
 	"github.com/pkg/errors"
+	"math"
+	"time"
 )
 
 type Timer interface {
-	// TimeoutForRound will reset running timer if exists and will start a new timer for a specific round
-	TimeoutForRound(round Round)
+	// TimeoutForRound will reset running timer if exists and will start a new timer for a specific round, firing after timeout
+	TimeoutForRound(round Round, timeout time.Duration)
 }
 
-// RoundTimeout returns the number of seconds until next timeout for a give round
-func (i *Instance) RoundTimeout(round Round) uint64 {
-	return powerOf2(uint64(round))
+// StoppableTimer is a Timer that can stop its running timer (e.g. RoundTimer), stopped instances stop their timer if it implements StoppableTimer
+type StoppableTimer interface {
+	Timer
+	// Stop stops the running timer if exists
+	Stop()
 }
 
-func powerOf2(exp uint64) uint64 {
-	if exp == 0 {
-		return 1
-	} else {
-		return 2 * powerOf2(exp-1)
+// InstanceTimerF returns a new round timer for the instance of identifier and height
+type InstanceTimerF func(identifier []byte, height Height) Timer
+
+// RoundTimeoutPolicy returns the duration until timeout for a given round
+type RoundTimeoutPolicy func(state *State, round Round) time.Duration
+
+// DefaultRoundTimeoutPolicy is used when no RoundTimeoutPolicy is configured, 2^round seconds
+var DefaultRoundTimeoutPolicy = ExponentialRoundTimeout(time.Second)
+
+// ExponentialRoundTimeout returns a policy of base * 2^round
+func ExponentialRoundTimeout(base time.Duration) RoundTimeoutPolicy {
+	return func(state *State, round Round) time.Duration {
+		ret := base
+		if ret <= 0 {
+			return ret
+		}
+		for r := Round(0); r < round; r++ {
+			if ret > math.MaxInt64/2 {
+				return math.MaxInt64
+			}
+			ret *= 2
+		}
+		return ret
+	}
+}
+
+// LinearCappedRoundTimeout returns a policy starting at base for the first round, adding step for every following round and capped at max
+func LinearCappedRoundTimeout(base time.Duration, step time.Duration, max time.Duration) RoundTimeoutPolicy {
+	return func(state *State, round Round) time.Duration {
+		if round <= FirstRound {
+			return minDuration(base, max)
+		}
+		steps := uint64(round - FirstRound)
+		if step > 0 && steps > uint64((max-base)/step) {
+			return max
+		}
+		return minDuration(base+step*time.Duration(steps), max)
+	}
+}
+
+// QuickSlowRoundTimeout returns a policy with a quick phase and a slow phase counted in rounds.
+// The first quickRounds rounds time out after quickTimeout, all following rounds time out after slowTimeout.
+// The phases don't depend on the duty's slot, see SlotQuickSlowRoundTimeout for a slot-aware policy
+func QuickSlowRoundTimeout(quickTimeout time.Duration, slowTimeout time.Duration, quickRounds Round) RoundTimeoutPolicy {
+	return func(state *State, round Round) time.Duration {
+		if round <= quickRounds {
+			return quickTimeout
+		}
+		return slowTimeout
+	}
+}
+
+// SlotStartF returns the start time of the beacon slot of the duty an instance runs for (e.g. looked up by the instance's identifier and height), zero time if unknown
+type SlotStartF func(state *State) time.Time
+
+// SlotQuickSlowRoundTimeout returns a slot-aware policy with a quick phase and a slow phase.
+// Rounds starting within quickPhase of the duty's slot start time out after quickTimeout so the duty can still complete within its beacon deadline,
+// rounds starting later (or with an unknown slot start) time out after slowTimeout to let a lagging committee converge.
+// now returns the current time, time.Now is used if nil
+func SlotQuickSlowRoundTimeout(slotStartF SlotStartF, now func() time.Time, quickTimeout time.Duration, slowTimeout time.Duration, quickPhase time.Duration) RoundTimeoutPolicy {
+	if now == nil {
+		now = time.Now
+	}
+	return func(state *State, round Round) time.Duration {
+		slotStart := slotStartF(state)
+		if slotStart.IsZero() || now().Sub(slotStart) >= quickPhase {
+			return slowTimeout
+		}
+		return quickTimeout
+	}
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// RoundTimeout returns the duration until next timeout for a give round according to the configured RoundTimeoutPolicy
+func (i *Instance) RoundTimeout(round Round) time.Duration {
+	policy := i.config.GetRoundTimeoutPolicy()
+	if policy == nil {
+		policy = DefaultRoundTimeoutPolicy
 	}
+	return policy(i.State, round)
+}
+
+// timeoutForRound resets the round timer for round with the timeout calculated by the RoundTimeoutPolicy
+func (i *Instance) timeoutForRound(round Round) {
+	i.GetTimer().TimeoutForRound(round, i.RoundTimeout(round))
+}
+
+// GetTimer returns the instance's own round timer if the config has an InstanceTimerF, the config's shared timer otherwise
+func (i *Instance) GetTimer() Timer {
+	if i.timer != nil {
+		return i.timer
+	}
+	timerF := i.config.GetInstanceTimerF()
+	if timerF == nil {
+		return i.config.GetTimer()
+	}
+	i.timer = timerF(i.State.ID, i.State.Height)
+	return i.timer
 }
 
 func (i *Instance) UponRoundTimeout() error {
@@ -27,7 +129,8 @@ func (i *Instance) UponRoundTimeout() error {
 	defer func() {
 		i.State.Round = newRound
 		i.State.ProposalAcceptedForCurrentRound = nil
-		i.config.GetTimer().TimeoutForRound(i.State.Round)
+		i.timeoutForRound(i.State.Round)
+		i.emit(RoundChangedEvent, nil, nil, nil)
 	}()
 
 	roundChange, err := CreateRoundChange(i.State, i.config, newRound, i.StartValue)
@@ -41,3 +144,37 @@ func (i *Instance) UponRoundTimeout() error {
 
 	return nil
 }
+
+// ProcessTimeout processes a round timeout fired by the Timer, serialized with ProcessMsg.
+// A timeout for a round other than the current round (stale timer), after the instance decided or was stopped is ignored.
+// Timeouts of a controller's instances should be processed by Controller.UponRoundTimeout, which checkpoints the controller (and broadcasts the msgs held until checkpointed)
+func (i *Instance) ProcessTimeout(round Round) error {
+	res := i.processMsgF.Run(func() interface{} {
+		if i.State.Decided || i.State.Stopped || round != i.State.Round {
+			return nil
+		}
+		defer i.checkInvariants(nil, i.snapshotInvariants())
+		return i.UponRoundTimeout()
+	})
+	if res != nil {
+		return res.(error)
+	}
+	return nil
+}
+
+// UponRoundTimeout processes a round timeout for the instance at height and checkpoints the controller, serialized with ProcessMsg
+func (c *Controller) UponRoundTimeout(height Height, round Round) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	inst := c.InstanceForHeight(height)
+	if inst == nil {
+		return errors.New("instance not found")
+	}
+
+	err := inst.ProcessTimeout(round)
+	if checkpointErr := c.checkpoint(); checkpointErr != nil {
+		return errors.Wrap(checkpointErr, "could not checkpoint controller")
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package qbft
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestExponentialRoundTimeout(t *testing.T) {
+	policy := ExponentialRoundTimeout(time.Second)
+	require.EqualValues(t, 2*time.Second, policy(nil, 1))
+	require.EqualValues(t, 4*time.Second, policy(nil, 2))
+	require.EqualValues(t, 16*time.Second, policy(nil, 4))
+	require.EqualValues(t, time.Duration(math.MaxInt64), policy(nil, 100))
+
+	t.Run("zero base", func(t *testing.T) {
+		require.EqualValues(t, 0, ExponentialRoundTimeout(0)(nil, math.MaxUint64))
+	})
+}
+
+func TestLinearCappedRoundTimeout(t *testing.T) {
+	policy := LinearCappedRoundTimeout(2*time.Second, time.Second, 5*time.Second)
+	require.EqualValues(t, 2*time.Second, policy(nil, 1))
+	require.EqualValues(t, 3*time.Second, policy(nil, 2))
+	require.EqualValues(t, 5*time.Second, policy(nil, 4))
+	require.EqualValues(t, 5*time.Second, policy(nil, 5))
+	require.EqualValues(t, 5*time.Second, policy(nil, math.MaxUint64))
+}
+
+func TestQuickSlowRoundTimeout(t *testing.T) {
+	policy := QuickSlowRoundTimeout(2*time.Second, 2*time.Minute, 3)
+	require.EqualValues(t, 2*time.Second, policy(nil, 1))
+	require.EqualValues(t, 2*time.Second, policy(nil, 3))
+	require.EqualValues(t, 2*time.Minute, policy(nil, 4))
+	require.EqualValues(t, 2*time.Minute, policy(nil, 20))
+}
+
+func TestSlotQuickSlowRoundTimeout(t *testing.T) {
+	slotStart := time.Unix(1600000000, 0)
+	now := slotStart
+	policy := SlotQuickSlowRoundTimeout(
+		func(state *State) time.Time {
+			if state.Height == FirstHeight {
+				return slotStart
+			}
+			return time.Time{}
+		},
+		func() time.Time {
+			return now
+		},
+		2*time.Second,
+		2*time.Minute,
+		4*time.Second,
+	)
+	state := &State{Height: FirstHeight}
+
+	require.EqualValues(t, 2*time.Second, policy(state, 1))
+	now = slotStart.Add(3 * time.Second)
+	require.EqualValues(t, 2*time.Second, policy(state, 2))
+	now = slotStart.Add(4 * time.Second)
+	require.EqualValues(t, 2*time.Minute, policy(state, 2))
+	require.EqualValues(t, 2*time.Minute, policy(&State{Height: 1}, 1)) // unknown slot start
+}
+
+func TestInstance_RoundTimeout(t *testing.T) {
+	t.Run("default policy", func(t *testing.T) {
+		i := NewInstance(&Config{}, testingShare, []byte{1, 2, 3, 4}, FirstHeight)
+		require.EqualValues(t, 2*time.Second, i.RoundTimeout(1))
+		require.EqualValues(t, 16*time.Second, i.RoundTimeout(4))
+	})
+
+	t.Run("configured policy", func(t *testing.T) {
+		i := NewInstance(&Config{RoundTimeoutPolicy: QuickSlowRoundTimeout(2*time.Second, 2*time.Minute, 3)}, testingShare, []byte{1, 2, 3, 4}, FirstHeight)
+		require.EqualValues(t, 2*time.Second, i.RoundTimeout(1))
+		require.EqualValues(t, 2*time.Minute, i.RoundTimeout(4))
+	})
+
+	t.Run("timer gets policy timeout", func(t *testing.T) {
+		timer := &testingTimer{}
+		i := NewInstance(&Config{Timer: timer, RoundTimeoutPolicy: LinearCappedRoundTimeout(2*time.Second, time.Second, 5*time.Second)}, testingShare, []byte{1, 2, 3, 4}, FirstHeight)
+		i.timeoutForRound(3)
+		require.EqualValues(t, []Round{3}, timer.rounds)
+		require.EqualValues(t, []time.Duration{4 * time.Second}, timer.timeouts)
+	})
+}
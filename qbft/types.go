@@ -34,6 +34,32 @@ type Storage interface {
 	GetHighestDecided(identifier []byte) (*SignedMessage, error)
 }
 
+// HistoricalStorage is an optional Storage extension which keeps every Decided msg (not just the highest).
+// Nodes supporting the decided history sync protocol should provide a storage implementing it.
+type HistoricalStorage interface {
+	Storage
+	// SaveDecided saves (and potentially overrides) the Decided msg for its identifier and height
+	SaveDecided(signedMsg *SignedMessage) error
+	// GetDecided returns all Decided msgs found for identifier between from and to heights (inclusive), sorted by height
+	GetDecided(identifier []byte, from Height, to Height) ([]*SignedMessage, error)
+}
+
+// ControllerStorage persists encoded Controller snapshots (including its stored instances) for crash recovery
+type ControllerStorage interface {
+	// SaveControllerSnapshot saves (and overrides) the encoded controller snapshot for a specific controller identifier
+	SaveControllerSnapshot(identifier []byte, data []byte) error
+	// GetControllerSnapshot returns the encoded controller snapshot if found, nil if didn't
+	GetControllerSnapshot(identifier []byte) ([]byte, error)
+}
+
+// EquivocationStorage is an optional Storage extension persisting equivocation evidence for slashing/reputation purposes
+type EquivocationStorage interface {
+	// SaveEquivocationEvidence saves (and potentially overrides) the evidence for its signer, height, round and msg type
+	SaveEquivocationEvidence(evidence *EquivocationEvidence) error
+	// GetEquivocationEvidence returns all evidence saved for identifier
+	GetEquivocationEvidence(identifier []byte) ([]*EquivocationEvidence, error)
+}
+
 func ControllerIdToMessageID(identifier []byte) types.MessageID {
 	ret := types.MessageID{}
 	copy(ret[:], identifier)
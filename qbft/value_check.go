@@ -0,0 +1,146 @@
+package qbft
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"github.com/bloxapp/ssv-spec/types"
+	"sync"
+)
+
+// DefaultValueCheckCacheCapacity is the number of value check results cached by NewCachedValueCheckF if no capacity is set
+const DefaultValueCheckCacheCapacity = 64
+
+type valueCheckResult struct {
+	done chan struct{}
+	err  error
+}
+
+// valueCheckCache caches successful value check results by value root, concurrent checks of the same value share a single valCheck call.
+// Failed checks are not cached (a value can become valid, e.g. once a slashing protection db caught up), results are evicted in insertion order once capacity is reached
+type valueCheckCache struct {
+	valCheck ProposedValueCheckF
+	capacity int
+
+	mtx     sync.Mutex
+	results map[[32]byte]*valueCheckResult
+	order   [][32]byte
+}
+
+// NewCachedValueCheckF returns a ProposedValueCheckF calling valCheck once per valid value (by value root) for the last capacity values checked (DefaultValueCheckCacheCapacity if capacity < 1), invalid values are checked again every call.
+// Use for expensive value checks called repeatedly for the same value (proposal, round change justification and instance start checks)
+func NewCachedValueCheckF(valCheck ProposedValueCheckF, capacity int) ProposedValueCheckF {
+	if capacity < 1 {
+		capacity = DefaultValueCheckCacheCapacity
+	}
+	cache := &valueCheckCache{
+		valCheck: valCheck,
+		capacity: capacity,
+		results:  make(map[[32]byte]*valueCheckResult),
+		order:    make([][32]byte, 0),
+	}
+	return cache.check
+}
+
+func (c *valueCheckCache) check(data []byte) error {
+	root := sha256.Sum256(data)
+
+	c.mtx.Lock()
+	res, found := c.results[root]
+	if !found {
+		res = &valueCheckResult{done: make(chan struct{})}
+		c.add(root, res)
+	}
+	c.mtx.Unlock()
+
+	if !found {
+		res.err = c.valCheck(data)
+		close(res.done)
+		if res.err != nil {
+			c.mtx.Lock()
+			c.remove(root, res)
+			c.mtx.Unlock()
+		}
+	}
+	<-res.done
+	return res.err
+}
+
+// remove removes res for root if not evicted already, must be called with mtx locked
+func (c *valueCheckCache) remove(root [32]byte, res *valueCheckResult) {
+	if c.results[root] != res {
+		return
+	}
+	delete(c.results, root)
+	for idx, r := range c.order {
+		if r == root {
+			c.order = append(c.order[:idx], c.order[idx+1:]...)
+			break
+		}
+	}
+}
+
+// add stores res for root, must be called with mtx locked
+func (c *valueCheckCache) add(root [32]byte, res *valueCheckResult) {
+	if len(c.order) >= c.capacity {
+		delete(c.results, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.results[root] = res
+	c.order = append(c.order, root)
+}
+
+// preCheckValue checks the value a proposal or prepared round change msg carries ahead of msg processing (see Config.AsyncValueCheck).
+// Only values of msgs for the instance's height with a valid signature (and of the round's leader for proposals) are checked.
+// The check runs on the caller's goroutine outside processMsgF, the instance processes msgs from other goroutines meanwhile.
+// Returns a ProposedValueCheckF returning the pre checked value's result, any other value is checked with valCheck.
+// Msgs failing the validation, of other types or without a decodable value are not pre checked (and rejected by msg processing)
+func (i *Instance) preCheckValue(msg *SignedMessage, valCheck ProposedValueCheckF) ProposedValueCheckF {
+	var value []byte
+	i.processMsgF.Run(func() interface{} {
+		value = i.valueToPreCheck(msg)
+		return nil
+	})
+	if value == nil {
+		return valCheck
+	}
+	if err := verifyMsgSignature(i.config, msg, i.State.Share.Committee); err != nil {
+		return valCheck
+	}
+	msg.markSignatureVerified(i.config, i.State.Share.Committee)
+
+	checkErr := valCheck(value)
+	return func(data []byte) error {
+		if !bytes.Equal(data, value) {
+			return valCheck(data)
+		}
+		return checkErr
+	}
+}
+
+// valueToPreCheck returns the value msg carries if it's a single signer msg for the instance's height (of the round's leader for proposals), nil otherwise.
+// Must be called with processMsgF as it reads the instance's state
+func (i *Instance) valueToPreCheck(msg *SignedMessage) []byte {
+	if msg.Message.Height != i.State.Height || len(msg.GetSigners()) != 1 {
+		return nil
+	}
+
+	switch msg.Message.MsgType {
+	case ProposalMsgType:
+		if !msg.MatchedSigners([]types.OperatorID{proposer(i.State, i.config, msg.Message.Round)}) {
+			return nil
+		}
+		proposalData, err := msg.Message.GetProposalData()
+		if err != nil {
+			return nil
+		}
+		return proposalData.Data
+	case RoundChangeMsgType:
+		rcData, err := msg.Message.GetRoundChangeData()
+		if err != nil || !rcData.Prepared() {
+			return nil
+		}
+		return rcData.PreparedValue
+	default:
+		return nil
+	}
+}
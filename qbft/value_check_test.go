@@ -0,0 +1,163 @@
+package qbft_test
+
+import (
+	"bytes"
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewCachedValueCheckF(t *testing.T) {
+	var calls int32
+	valCheck := qbft.NewCachedValueCheckF(func(data []byte) error {
+		atomic.AddInt32(&calls, 1)
+		if bytes.Equal(data, []byte{1, 2, 3, 5}) {
+			return errors.New("invalid value")
+		}
+		return nil
+	}, 2)
+
+	t.Run("cached", func(t *testing.T) {
+		require.NoError(t, valCheck([]byte{1, 2, 3, 4}))
+		require.NoError(t, valCheck([]byte{1, 2, 3, 4}))
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("failures not cached", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		require.EqualError(t, valCheck([]byte{1, 2, 3, 5}), "invalid value")
+		require.EqualError(t, valCheck([]byte{1, 2, 3, 5}), "invalid value")
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+		require.NoError(t, valCheck([]byte{1, 2, 3, 4})) // not evicted by failures
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		require.NoError(t, valCheck([]byte{1, 2, 3, 6}))
+		require.NoError(t, valCheck([]byte{1, 2, 3, 8})) // evicts {1, 2, 3, 4}
+		require.NoError(t, valCheck([]byte{1, 2, 3, 4}))
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.NoError(t, valCheck([]byte{1, 2, 3, 7}))
+			}()
+		}
+		wg.Wait()
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+}
+
+func TestInstance_AsyncValueCheck(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	checking := make(chan struct{})
+	release := make(chan struct{})
+	config := testingutils.TestingConfig(ks)
+	config.AsyncValueCheck = true
+	config.ValueCheckF = func(data []byte) error {
+		if bytes.Equal(data, []byte{1, 2, 3, 5}) {
+			close(checking)
+			<-release
+		}
+		return nil
+	}
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+	require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+	inst := c.InstanceForHeight(qbft.FirstHeight)
+
+	proposed := make(chan error)
+	go func() {
+		_, _, _, err := inst.ProcessMsg(testingutils.SignQBFTMsg(ks.Shares[1], 1, &qbft.Message{
+			MsgType:    qbft.ProposalMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: identifier[:],
+			Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 5}, nil, nil),
+		}))
+		proposed <- err
+	}()
+	<-checking
+
+	// the instance processes other msgs while the proposal's value is checked
+	processed := make(chan error)
+	go func() {
+		_, _, _, err := inst.ProcessMsg(testingutils.SignQBFTMsg(ks.Shares[2], 2, &qbft.Message{
+			MsgType:    qbft.RoundChangeMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      2,
+			Identifier: identifier[:],
+			Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+		}))
+		processed <- err
+	}()
+	select {
+	case err := <-processed:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "msg processing blocked by value check")
+	}
+
+	close(release)
+	require.NoError(t, <-proposed)
+	require.NotNil(t, inst.State.ProposalAcceptedForCurrentRound)
+}
+
+func TestInstance_AsyncValueCheckValidatesFirst(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	var calls int32
+	config := testingutils.TestingConfig(ks)
+	config.AsyncValueCheck = true
+	config.ValueCheckF = func(data []byte) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+	require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+	inst := c.InstanceForHeight(qbft.FirstHeight)
+	atomic.StoreInt32(&calls, 0)
+
+	proposal := func(signer types.OperatorID) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[signer], signer, &qbft.Message{
+			MsgType:    qbft.ProposalMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      qbft.FirstRound,
+			Identifier: identifier[:],
+			Data:       testingutils.ProposalDataBytes([]byte{1, 2, 3, 5}, nil, nil),
+		})
+	}
+
+	t.Run("leader invalid", func(t *testing.T) {
+		_, _, _, err := inst.ProcessMsg(proposal(2))
+		require.EqualError(t, err, "proposal invalid: proposal leader invalid")
+		require.EqualValues(t, 0, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("signature invalid", func(t *testing.T) {
+		msg := proposal(1)
+		msg.Signature = proposal(2).Signature
+		_, _, _, err := inst.ProcessMsg(msg)
+		require.EqualError(t, err, "proposal invalid: proposal msg signature invalid: failed to verify signature")
+		require.EqualValues(t, 0, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		_, _, _, err := inst.ProcessMsg(proposal(1))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+}
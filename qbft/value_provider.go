@@ -0,0 +1,57 @@
+package qbft
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ValueProvider provides fresh values for a leader to propose after a round change without a prepared value,
+// instead of the instance's start value which might be stale by then (e.g. attestation data from the start of the slot)
+type ValueProvider interface {
+	// GetValue returns the value to propose for the identifier's instance at height and round.
+	// Called synchronously while processing msgs and should not block for long
+	GetValue(identifier []byte, height Height, round Round) ([]byte, error)
+}
+
+// ValueProviderF is a func implementing ValueProvider
+type ValueProviderF func(identifier []byte, height Height, round Round) ([]byte, error)
+
+// GetValue calls f with identifier, height and round
+func (f ValueProviderF) GetValue(identifier []byte, height Height, round Round) ([]byte, error) {
+	return f(identifier, height, round)
+}
+
+// providedValue is the value chosen by valueForRound for a height and round
+type providedValue struct {
+	height Height
+	round  Round
+	value  []byte
+}
+
+// valueForRound returns the value to propose for the current round when no value was prepared, provided by the config's ValueProvider if set.
+// Falls back to instanceStartValue if the provider fails or provides a value not passing the value check.
+// The provider is called once per height and round, re-proposals for the round (e.g. on round change msgs beyond the quorum) reuse its value so the leader doesn't equivocate
+func (i *Instance) valueForRound(instanceStartValue []byte) []byte {
+	provider := i.config.GetValueProvider()
+	if provider == nil {
+		return instanceStartValue
+	}
+	if i.providedValue != nil && i.providedValue.height == i.State.Height && i.providedValue.round == i.State.Round {
+		return i.providedValue.value
+	}
+
+	value, err := provider.GetValue(i.State.ID, i.State.Height, i.State.Round)
+	if err != nil {
+		i.logError(errors.Wrap(err, "could not get value from value provider"))
+		value = instanceStartValue
+	} else if err := i.config.GetValueCheckF()(value); err != nil {
+		i.logError(errors.Wrap(err, "provided value invalid"))
+		value = instanceStartValue
+	}
+
+	i.providedValue = &providedValue{
+		height: i.State.Height,
+		round:  i.State.Round,
+		value:  value,
+	}
+	return value
+}
@@ -0,0 +1,106 @@
+package qbft_test
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInstance_ValueProvider(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	identifier := types.NewMsgID(testingutils.TestingValidatorPubKey[:], types.BNRoleAttester)
+	rc := func(id types.OperatorID, round qbft.Round) *qbft.SignedMessage {
+		return testingutils.SignQBFTMsg(ks.Shares[id], id, &qbft.Message{
+			MsgType:    qbft.RoundChangeMsgType,
+			Height:     qbft.FirstHeight,
+			Round:      round,
+			Identifier: identifier[:],
+			Data:       testingutils.RoundChangeDataBytes(nil, qbft.NoRound),
+		})
+	}
+	// proposedValue changes the round of a started controller's instance to round and returns the value the leader proposed for it
+	proposedValue := func(config *qbft.Config, round qbft.Round) []byte {
+		c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+		require.NoError(t, c.ProcessSyncHighestRoundChange([]*qbft.SignedMessage{rc(2, round), rc(3, round), rc(4, round)}))
+
+		net := config.GetNetwork().(*testingutils.TestingNetwork)
+		msg := &qbft.SignedMessage{}
+		require.NoError(t, msg.Decode(net.BroadcastedMsgs[len(net.BroadcastedMsgs)-1].Data))
+		require.EqualValues(t, qbft.ProposalMsgType, msg.Message.MsgType)
+		require.EqualValues(t, round, msg.Message.Round)
+		data, err := msg.Message.GetProposalData()
+		require.NoError(t, err)
+		return data.Data
+	}
+
+	t.Run("fresh value", func(t *testing.T) {
+		config := testingutils.TestingConfig(ks)
+		rounds := make([]qbft.Round, 0)
+		config.ValueProvider = qbft.ValueProviderF(func(identifier []byte, height qbft.Height, round qbft.Round) ([]byte, error) {
+			rounds = append(rounds, round)
+			return []byte{1, 2, 3, 5}, nil
+		})
+		require.EqualValues(t, []byte{1, 2, 3, 5}, proposedValue(config, 3))
+		require.EqualValues(t, []qbft.Round{3}, rounds) // not called for the first round
+		require.Empty(t, config.Logger.(*testingutils.TestingLogger).Errors())
+	})
+
+	t.Run("provider failure", func(t *testing.T) {
+		config := testingutils.TestingConfig(ks)
+		config.ValueProvider = qbft.ValueProviderF(func(identifier []byte, height qbft.Height, round qbft.Round) ([]byte, error) {
+			return nil, errors.New("beacon node unavailable")
+		})
+		require.EqualValues(t, []byte{1, 2, 3, 4}, proposedValue(config, 2))
+		require.EqualValues(t, []string{"could not get value from value provider: beacon node unavailable"}, config.Logger.(*testingutils.TestingLogger).Errors())
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		config := testingutils.TestingConfig(ks)
+		config.ValueProvider = qbft.ValueProviderF(func(identifier []byte, height qbft.Height, round qbft.Round) ([]byte, error) {
+			return testingutils.TestingInvalidValueCheck, nil
+		})
+		require.EqualValues(t, []byte{1, 2, 3, 4}, proposedValue(config, 2))
+		require.EqualValues(t, []string{"provided value invalid: invalid value"}, config.Logger.(*testingutils.TestingLogger).Errors())
+	})
+
+	t.Run("same value for round", func(t *testing.T) {
+		config := testingutils.TestingConfig(ks)
+		calls := 0
+		config.ValueProvider = qbft.ValueProviderF(func(identifier []byte, height qbft.Height, round qbft.Round) ([]byte, error) {
+			calls++
+			return []byte{1, 2, 3, byte(4 + calls)}, nil
+		})
+		c := testingutils.NewTestingQBFTController(identifier[:], testingutils.TestingShare(ks), config)
+		require.NoError(t, c.StartNewInstance([]byte{1, 2, 3, 4}))
+
+		// round change msgs beyond the quorum re-propose for the round
+		for _, id := range []types.OperatorID{2, 3, 4, 1} {
+			_, err := c.ProcessMsg(rc(id, 2))
+			require.NoError(t, err)
+		}
+
+		net := config.GetNetwork().(*testingutils.TestingNetwork)
+		proposals := 0
+		for _, broadcasted := range net.BroadcastedMsgs {
+			msg := &qbft.SignedMessage{}
+			require.NoError(t, msg.Decode(broadcasted.Data))
+			if msg.Message.MsgType != qbft.ProposalMsgType || msg.Message.Round != 2 {
+				continue
+			}
+			data, err := msg.Message.GetProposalData()
+			require.NoError(t, err)
+			require.EqualValues(t, []byte{1, 2, 3, 5}, data.Data)
+			proposals++
+		}
+		require.Greater(t, proposals, 1)
+		require.EqualValues(t, 1, calls)
+	})
+
+	t.Run("no provider", func(t *testing.T) {
+		require.EqualValues(t, []byte{1, 2, 3, 4}, proposedValue(testingutils.TestingConfig(ks), 2))
+	})
+}
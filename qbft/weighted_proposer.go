@@ -0,0 +1,106 @@
+package qbft
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/bloxapp/ssv-spec/types"
+	"math/bits"
+)
+
+// MaxWeightedProposerScore bounds the scores WeightedProposer rotates by, higher scores are scaled down proportionally (non zero scores stay above 0)
+const MaxWeightedProposerScore uint64 = 1000
+
+// WeightedProposer returns a ProposerF rotating proposers in proportion to their score.
+// The rotation is a smooth weighted round robin over the committee (interleaving high score operators rather than giving them consecutive rounds),
+// its starting point is seeded from State.ID and State.Height so all nodes agree on the proposer while every height starts elsewhere.
+// Operators missing from scores (or with score 0) never propose, if no operator has a score it falls back to RoundRobinProposer.
+// The rotation length is the sum of the (gcd reduced) scores, bounded by scaling scores down to MaxWeightedProposerScore.
+func WeightedProposer(scores map[types.OperatorID]uint64) ProposerF {
+	scores = boundedScores(scores)
+	return func(state *State, round Round) types.OperatorID {
+		weights, total := reducedWeights(state.Share.Committee, scores)
+		if total == 0 {
+			return RoundRobinProposer(state, round)
+		}
+
+		index := (proposerSeed(state)%uint64(total) + uint64(round-FirstRound)%uint64(total)) % uint64(total)
+		return state.Share.Committee[weightedSequenceAt(weights, total, int64(index))].OperatorID
+	}
+}
+
+// boundedScores returns a copy of scores scaled down proportionally if any score is above MaxWeightedProposerScore
+func boundedScores(scores map[types.OperatorID]uint64) map[types.OperatorID]uint64 {
+	max := uint64(0)
+	for _, score := range scores {
+		if score > max {
+			max = score
+		}
+	}
+
+	ret := make(map[types.OperatorID]uint64, len(scores))
+	for id, score := range scores {
+		if max > MaxWeightedProposerScore && score > 0 {
+			// score * MaxWeightedProposerScore / max without overflowing, score <= max so the quotient fits
+			hi, lo := bits.Mul64(score, MaxWeightedProposerScore)
+			score, _ = bits.Div64(hi, lo, max)
+			if score == 0 {
+				score = 1
+			}
+		}
+		ret[id] = score
+	}
+	return ret
+}
+
+// proposerSeed returns a deterministic seed for the state's identifier and height
+func proposerSeed(state *State) uint64 {
+	heightByts := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightByts, uint64(state.Height))
+
+	h := sha256.Sum256(append(append([]byte{}, state.ID...), heightByts...))
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+// reducedWeights returns the gcd reduced scores of the committee (in committee order) and their sum, 0 if no operator has a score
+func reducedWeights(committee []*types.Operator, scores map[types.OperatorID]uint64) ([]int64, int64) {
+	divisor := uint64(0)
+	for _, operator := range committee {
+		divisor = gcd(divisor, scores[operator.OperatorID])
+	}
+	if divisor == 0 {
+		return nil, 0
+	}
+
+	weights := make([]int64, len(committee))
+	total := int64(0)
+	for i, operator := range committee {
+		weights[i] = int64(scores[operator.OperatorID] / divisor)
+		total += weights[i]
+	}
+	return weights, total
+}
+
+// weightedSequenceAt returns the committee index at position index of the smooth weighted round robin sequence for weights, whose length is total.
+// Ties are broken by committee order.
+func weightedSequenceAt(weights []int64, total int64, index int64) int {
+	current := make([]int64, len(weights))
+	best := -1
+	for k := int64(0); k <= index; k++ {
+		best = -1
+		for i := range weights {
+			current[i] += weights[i]
+			if best == -1 || current[i] > current[best] {
+				best = i
+			}
+		}
+		current[best] -= total
+	}
+	return best
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,33 @@
+package qbft
+
+import (
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestWeightedProposer_BoundedScores(t *testing.T) {
+	committee := []*types.Operator{{OperatorID: 1}, {OperatorID: 2}, {OperatorID: 3}}
+	state := &State{Share: &types.Share{Committee: committee}, ID: []byte{1, 2, 3, 4}, Height: FirstHeight}
+
+	t.Run("large scores", func(t *testing.T) {
+		scores := boundedScores(map[types.OperatorID]uint64{1: 1e9 + 7, 2: 1, 3: math.MaxUint64})
+		require.EqualValues(t, map[types.OperatorID]uint64{1: 1, 2: 1, 3: MaxWeightedProposerScore}, scores)
+
+		_, total := reducedWeights(committee, scores)
+		require.EqualValues(t, MaxWeightedProposerScore+2, total)
+
+		proposerF := WeightedProposer(map[types.OperatorID]uint64{1: 1e9 + 7, 2: 1, 3: math.MaxUint64})
+		proposed := make(map[types.OperatorID]int)
+		for round := FirstRound; round <= Round(total); round++ {
+			proposed[proposerF(state, round)]++
+		}
+		require.EqualValues(t, map[types.OperatorID]int{1: 1, 2: 1, 3: int(MaxWeightedProposerScore)}, proposed)
+	})
+
+	t.Run("small scores unchanged", func(t *testing.T) {
+		scores := map[types.OperatorID]uint64{1: 3, 2: 1, 3: 0}
+		require.EqualValues(t, scores, boundedScores(scores))
+	})
+}
@@ -204,7 +204,12 @@ func fixRunnerForRun(t *testing.T, baseRunner map[string]interface{}, ks *testin
 	ret.GetBaseRunner().QBFTController = fixControllerForRun(t, ret, ret.GetBaseRunner().QBFTController, ks)
 	if ret.GetBaseRunner().State != nil {
 		if ret.GetBaseRunner().State.RunningInstance != nil {
-			ret.GetBaseRunner().State.RunningInstance = fixInstanceForRun(t, ret.GetBaseRunner().State.RunningInstance, ret.GetBaseRunner().QBFTController, ret.GetBaseRunner().Share)
+			// the running instance is the controller's stored instance for its height, keep them the same instance as json decoding splits them
+			if inst := ret.GetBaseRunner().QBFTController.InstanceForHeight(ret.GetBaseRunner().State.RunningInstance.State.Height); inst != nil {
+				ret.GetBaseRunner().State.RunningInstance = inst
+			} else {
+				ret.GetBaseRunner().State.RunningInstance = fixInstanceForRun(t, ret.GetBaseRunner().State.RunningInstance, ret.GetBaseRunner().QBFTController, ret.GetBaseRunner().Share)
+			}
 		}
 	}
 	return ret
@@ -220,14 +225,12 @@ func fixControllerForRun(t *testing.T, runner ssv.Runner, contr *qbft.Controller
 	)
 	newContr.Height = contr.Height
 	newContr.Domain = contr.Domain
-	newContr.StoredInstances = contr.StoredInstances
 
-	for i, inst := range newContr.StoredInstances {
-		if inst == nil {
-			continue
-		}
-		newContr.StoredInstances[i] = fixInstanceForRun(t, inst, newContr, runner.GetBaseRunner().Share)
+	instances := make([]*qbft.Instance, 0)
+	for _, inst := range contr.StoredInstances.Instances() {
+		instances = append(instances, fixInstanceForRun(t, inst, newContr, runner.GetBaseRunner().Share))
 	}
+	newContr.StoredInstances = qbft.NewInstanceContainer(contr.StoredInstances.Capacity(), instances...)
 	return newContr
 }
 
@@ -22,7 +22,7 @@ func PostFinish() *tests.MultiMsgProcessingSpecTest {
 			r.GetBaseRunner().QBFTController.Identifier,
 			qbft.FirstHeight)
 		r.GetBaseRunner().State.RunningInstance.State.Decided = true
-		r.GetBaseRunner().QBFTController.StoredInstances[0] = r.GetBaseRunner().State.RunningInstance
+		r.GetBaseRunner().QBFTController.StoredInstances = qbft.NewInstanceContainer(qbft.HistoricalInstanceCapacity, r.GetBaseRunner().State.RunningInstance)
 		r.GetBaseRunner().QBFTController.Height = qbft.FirstHeight
 		r.GetBaseRunner().State.Finished = true
 		return r
@@ -45,12 +45,11 @@ func PostFinish() *tests.MultiMsgProcessingSpecTest {
 							Data:       testingutils.CommitDataBytes(testingutils.TestSyncCommitteeContributionConsensusDataByts),
 						}), nil),
 				},
-				PostDutyRunnerStateRoot: "3eabb4114c7d83045dfb678b9be3e00411d3b56bf71c8e4a496a2df5324f2394",
+				PostDutyRunnerStateRoot: "bfdfd2f862e609311ecdb992aa6785df9cdc081b1ebcca59d16f1c8170d5e3ed",
 				OutputMessages: []*ssv.SignedPartialSignatureMessage{
 					testingutils.PreConsensusContributionProofMsg(ks.Shares[1], ks.Shares[1], 1, 1),
 				},
 				DontStartDuty: true,
-				ExpectedError: "failed processing consensus message: could not process msg: did not receive proposal for this round",
 			},
 			{
 				Name:   "sync committee",
@@ -66,10 +65,9 @@ func PostFinish() *tests.MultiMsgProcessingSpecTest {
 							Data:       testingutils.CommitDataBytes(testingutils.TestSyncCommitteeConsensusDataByts),
 						}), nil),
 				},
-				PostDutyRunnerStateRoot: "b6c1a644234ac0dd90129cf982ee98977b68353a3500f8ff84575bf881051e79",
+				PostDutyRunnerStateRoot: "89e3732a9f7a83ee8b076f58c40063c41b576dcd9c6f1e4ebf238f2c920ff3f5",
 				OutputMessages:          []*ssv.SignedPartialSignatureMessage{},
 				DontStartDuty:           true,
-				ExpectedError:           "failed processing consensus message: could not process msg: did not receive proposal for this round",
 			},
 			{
 				Name:   "aggregator",
@@ -85,12 +83,11 @@ func PostFinish() *tests.MultiMsgProcessingSpecTest {
 							Data:       testingutils.CommitDataBytes(testingutils.TestAggregatorConsensusDataByts),
 						}), nil),
 				},
-				PostDutyRunnerStateRoot: "44a9f79f826b06d5bb7f6eb8ee54206164d012f4d590d42f2175ff411c7b1734",
+				PostDutyRunnerStateRoot: "7e931fcb269b60f8fe5e9746f28b2ac69ea538f92cc8945c57bb889261ccbaea",
 				OutputMessages: []*ssv.SignedPartialSignatureMessage{
 					testingutils.PreConsensusSelectionProofMsg(ks.Shares[1], ks.Shares[1], 1, 1),
 				},
 				DontStartDuty: true,
-				ExpectedError: "failed processing consensus message: could not process msg: did not receive proposal for this round",
 			},
 			{
 				Name:   "proposer",
@@ -106,12 +103,11 @@ func PostFinish() *tests.MultiMsgProcessingSpecTest {
 							Data:       testingutils.CommitDataBytes(testingutils.TestProposerConsensusDataByts),
 						}), nil),
 				},
-				PostDutyRunnerStateRoot: "8d24fce7b09b83a5e6e055d623cb7695e3d68c5072d2920d0d9cc438c591b8a8",
+				PostDutyRunnerStateRoot: "c13f6c255f082bfd3713f2cc535dfdb63db36c553547bdda66c73cec12c2621b",
 				OutputMessages: []*ssv.SignedPartialSignatureMessage{
 					testingutils.PreConsensusRandaoMsg(testingutils.Testing4SharesSet().Shares[1], 1),
 				},
 				DontStartDuty: true,
-				ExpectedError: "failed processing consensus message: could not process msg: did not receive proposal for this round",
 			},
 			{
 				Name:   "attester",
@@ -127,10 +123,9 @@ func PostFinish() *tests.MultiMsgProcessingSpecTest {
 							Data:       testingutils.CommitDataBytes(testingutils.TestAttesterConsensusDataByts),
 						}), nil),
 				},
-				PostDutyRunnerStateRoot: "7132ad74ae93d549e7980440f5e0390ac3b3aefa9a6c3edb64080d94ee2e641a",
+				PostDutyRunnerStateRoot: "20f4672db31cbfc7e03f34c66bf260ddd58bb9fd6e9cf6e3b811aecbdd60e2f2",
 				OutputMessages:          []*ssv.SignedPartialSignatureMessage{},
 				DontStartDuty:           true,
-				ExpectedError:           "failed processing consensus message: could not process msg: did not receive proposal for this round",
 			},
 		},
 	}
@@ -36,7 +36,7 @@ func PostInvalidDecided() *MultiStartNewRunnerDutySpecTest {
 			r.GetBaseRunner().Share,
 			r.GetBaseRunner().QBFTController.Identifier,
 			qbft.FirstHeight)
-		r.GetBaseRunner().QBFTController.StoredInstances[0] = r.GetBaseRunner().State.RunningInstance
+		r.GetBaseRunner().QBFTController.StoredInstances = qbft.NewInstanceContainer(qbft.HistoricalInstanceCapacity, r.GetBaseRunner().State.RunningInstance)
 		r.GetBaseRunner().QBFTController.Height = qbft.FirstHeight
 
 		err := r.ProcessConsensus(testingutils.MultiSignQBFTMsg(
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// BatchVerifier collects (signature, public keys, signing root) tuples and verifies all of them with a single multi-pairing,
+// much cheaper than verifying each signature on its own
+type BatchVerifier struct {
+	sigs  []bls.Sign
+	pks   []bls.PublicKey
+	roots []byte // concatenated 32 byte signing roots
+}
+
+// NewBatchVerifier returns an empty BatchVerifier
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{
+		sigs:  make([]bls.Sign, 0),
+		pks:   make([]bls.PublicKey, 0),
+		roots: make([]byte, 0),
+	}
+}
+
+// Add adds a signature over a 32 byte signing root, verified by the aggregation of pks
+func (v *BatchVerifier) Add(s Signature, pks [][]byte, signingRoot []byte) error {
+	if len(signingRoot) != 32 {
+		return errors.New("signing root must be 32 bytes")
+	}
+	if len(pks) == 0 {
+		return errors.New("no public keys found")
+	}
+
+	sign := bls.Sign{}
+	if err := sign.Deserialize(s); err != nil {
+		return errors.Wrap(err, "failed to deserialize signature")
+	}
+
+	aggPK := bls.PublicKey{}
+	for i, pkByts := range pks {
+		pk := bls.PublicKey{}
+		if err := pk.Deserialize(pkByts); err != nil {
+			return errors.Wrap(err, "failed to deserialize public key")
+		}
+		if i == 0 {
+			aggPK = pk
+		} else {
+			aggPK.Add(&pk)
+		}
+	}
+
+	v.sigs = append(v.sigs, sign)
+	v.pks = append(v.pks, aggPK)
+	v.roots = append(v.roots, signingRoot...)
+	return nil
+}
+
+// AddByOperators adds data's signature, verified by data's signers out of operators (see Signature.VerifyByOperators)
+func (v *BatchVerifier) AddByOperators(data MessageSignature, domain DomainType, sigType SignatureType, operators []*Operator) error {
+	pks := make([][]byte, 0)
+	for _, id := range data.GetSigners() {
+		found := false
+		for _, n := range operators {
+			if id == n.GetID() {
+				pks = append(pks, n.GetPublicKey())
+				found = true
+			}
+		}
+		if !found {
+			return errors.New("unknown signer")
+		}
+	}
+
+	computedRoot, err := ComputeSigningRoot(data, ComputeSignatureDomain(domain, sigType))
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+	return v.Add(data.GetSignature(), pks, computedRoot)
+}
+
+// Len returns the number of added signatures
+func (v *BatchVerifier) Len() int {
+	return len(v.sigs)
+}
+
+// Verify returns true if all added signatures are valid (true if none added).
+// A false result doesn't tell which signature is invalid, each should be verified on its own
+func (v *BatchVerifier) Verify() bool {
+	if v.Len() == 0 {
+		return true
+	}
+	return bls.MultiVerify(v.sigs, v.pks, v.roots)
+}
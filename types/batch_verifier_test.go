@@ -0,0 +1,77 @@
+package types
+
+import (
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type testingBatchMsg struct {
+	root    []byte
+	sig     Signature
+	signers []OperatorID
+}
+
+func (m *testingBatchMsg) GetRoot() ([]byte, error)                   { return m.root, nil }
+func (m *testingBatchMsg) GetSignature() Signature                    { return m.sig }
+func (m *testingBatchMsg) GetSigners() []OperatorID                   { return m.signers }
+func (m *testingBatchMsg) MatchedSigners(ids []OperatorID) bool       { return false }
+func (m *testingBatchMsg) Aggregate(signedMsg MessageSignature) error { return nil }
+
+func TestBatchVerifier(t *testing.T) {
+	InitBLS()
+
+	sks := make([]*bls.SecretKey, 0)
+	operators := make([]*Operator, 0)
+	for i := 1; i <= 4; i++ {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		sks = append(sks, sk)
+		operators = append(operators, &Operator{OperatorID: OperatorID(i), PubKey: sk.GetPublicKey().Serialize()})
+	}
+
+	signMsg := func(root []byte, ids ...OperatorID) *testingBatchMsg {
+		signingRoot, err := ComputeSigningRoot(&testingBatchMsg{root: root}, ComputeSignatureDomain(PrimusTestnet, QBFTSignatureType))
+		require.NoError(t, err)
+		var agg *bls.Sign
+		for _, id := range ids {
+			sig := sks[id-1].SignByte(signingRoot)
+			if agg == nil {
+				agg = sig
+			} else {
+				agg.Add(sig)
+			}
+		}
+		return &testingBatchMsg{root: root, sig: agg.Serialize(), signers: ids}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		v := NewBatchVerifier()
+		require.True(t, v.Verify())
+		require.NoError(t, v.AddByOperators(signMsg([]byte{1, 2, 3, 4}, 1), PrimusTestnet, QBFTSignatureType, operators))
+		require.NoError(t, v.AddByOperators(signMsg([]byte{1, 2, 3, 4}, 2), PrimusTestnet, QBFTSignatureType, operators))
+		require.NoError(t, v.AddByOperators(signMsg([]byte{1, 2, 3, 5}, 1, 3, 4), PrimusTestnet, QBFTSignatureType, operators))
+		require.EqualValues(t, 3, v.Len())
+		require.True(t, v.Verify())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		v := NewBatchVerifier()
+		require.NoError(t, v.AddByOperators(signMsg([]byte{1, 2, 3, 4}, 1), PrimusTestnet, QBFTSignatureType, operators))
+		invalid := signMsg([]byte{1, 2, 3, 4}, 2)
+		invalid.signers = []OperatorID{3}
+		require.NoError(t, v.AddByOperators(invalid, PrimusTestnet, QBFTSignatureType, operators))
+		require.False(t, v.Verify())
+	})
+
+	t.Run("unknown signer", func(t *testing.T) {
+		v := NewBatchVerifier()
+		require.EqualError(t, v.AddByOperators(signMsg([]byte{1, 2, 3, 4}, 1), PrimusTestnet, QBFTSignatureType, operators[1:]), "unknown signer")
+		require.EqualValues(t, 0, v.Len())
+	})
+
+	t.Run("invalid signing root", func(t *testing.T) {
+		v := NewBatchVerifier()
+		require.EqualError(t, v.Add(Signature{}, [][]byte{operators[0].PubKey}, []byte{1, 2, 3}), "signing root must be 32 bytes")
+	})
+}
@@ -26,9 +26,10 @@ func (sigType SignatureType) Equal(other SignatureType) bool {
 }
 
 var (
-	QBFTSignatureType    SignatureType = [4]byte{1, 0, 0, 0}
-	PartialSignatureType SignatureType = [4]byte{2, 0, 0, 0}
-	DKGSignatureType     SignatureType = [4]byte{3, 0, 0, 0}
+	QBFTSignatureType                 SignatureType = [4]byte{1, 0, 0, 0}
+	PartialSignatureType              SignatureType = [4]byte{2, 0, 0, 0}
+	DKGSignatureType                  SignatureType = [4]byte{3, 0, 0, 0}
+	EquivocationEvidenceSignatureType SignatureType = [4]byte{4, 0, 0, 0}
 )
 
 // EncryptionCalls captures all RSA share encryption calls
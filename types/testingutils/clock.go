@@ -0,0 +1,91 @@
+package testingutils
+
+import (
+	"sync"
+	"time"
+)
+
+type testingClockTask struct {
+	at time.Duration
+	f  func()
+}
+
+// TestingClock is a virtual qbft.Clock, time only advances when Advance is called and due calls fire synchronously
+type TestingClock struct {
+	lock  sync.Mutex
+	now   time.Duration
+	tasks []*testingClockTask
+}
+
+func NewTestingClock() *TestingClock {
+	return &TestingClock{
+		tasks: make([]*testingClockTask, 0),
+	}
+}
+
+// AfterFunc schedules f to be called once the clock advanced by d, returns a func that cancels the call
+func (c *TestingClock) AfterFunc(d time.Duration, f func()) func() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	task := &testingClockTask{
+		at: c.now + d,
+		f:  f,
+	}
+	c.tasks = append(c.tasks, task)
+	return func() bool {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		for i, t := range c.tasks {
+			if t == task {
+				c.tasks = append(c.tasks[:i], c.tasks[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Advance moves the clock forward by d, firing (in order) every call that became due including calls scheduled while advancing
+func (c *TestingClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	target := c.now + d
+	for {
+		next := -1
+		for i, t := range c.tasks {
+			if t.at <= target && (next == -1 || t.at < c.tasks[next].at) {
+				next = i
+			}
+		}
+		if next == -1 {
+			break
+		}
+
+		task := c.tasks[next]
+		c.tasks = append(c.tasks[:next], c.tasks[next+1:]...)
+		c.now = task.at
+
+		c.lock.Unlock()
+		task.f()
+		c.lock.Lock()
+	}
+	c.now = target
+	c.lock.Unlock()
+}
+
+// Now returns the time elapsed since the clock was created
+func (c *TestingClock) Now() time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+// Pending returns the number of scheduled calls that didn't fire yet
+func (c *TestingClock) Pending() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.tasks)
+}
@@ -0,0 +1,45 @@
+package testingutils
+
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"sync"
+)
+
+type TestingLogEntry struct {
+	Err    string
+	Fields qbft.LogFields
+}
+
+// TestingLogger records all log entries, safe for concurrent use (e.g. logging from round timer goroutines)
+type TestingLogger struct {
+	lock    sync.Mutex
+	Entries []*TestingLogEntry
+}
+
+func NewTestingLogger() *TestingLogger {
+	return &TestingLogger{
+		Entries: make([]*TestingLogEntry, 0),
+	}
+}
+
+func (l *TestingLogger) Error(err error, fields qbft.LogFields) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.Entries = append(l.Entries, &TestingLogEntry{
+		Err:    err.Error(),
+		Fields: fields,
+	})
+}
+
+// Errors returns the errors of all log entries, in order
+func (l *TestingLogger) Errors() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	ret := make([]string, 0)
+	for _, e := range l.Entries {
+		ret = append(ret, e.Err)
+	}
+	return ret
+}
@@ -0,0 +1,27 @@
+package testingutils
+
+import "github.com/bloxapp/ssv-spec/qbft"
+
+// TestingObserver records all events it observes
+type TestingObserver struct {
+	Events []*qbft.Event
+}
+
+func NewTestingObserver() *TestingObserver {
+	return &TestingObserver{
+		Events: make([]*qbft.Event, 0),
+	}
+}
+
+func (o *TestingObserver) OnEvent(event *qbft.Event) {
+	o.Events = append(o.Events, event)
+}
+
+// EventTypes returns the types of all observed events, in order
+func (o *TestingObserver) EventTypes() []qbft.EventType {
+	ret := make([]qbft.EventType, 0)
+	for _, e := range o.Events {
+		ret = append(ret, e.Type)
+	}
+	return ret
+}
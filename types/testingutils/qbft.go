@@ -24,6 +24,7 @@ var TestingConfig = func(keySet *TestKeySet) *qbft.Config {
 		Storage: NewTestingStorage(),
 		Network: NewTestingNetwork(),
 		Timer:   NewTestingTimer(),
+		Logger:  NewTestingLogger(),
 	}
 }
 
@@ -5,19 +5,24 @@ import (
 	"github.com/bloxapp/ssv-spec/dkg"
 	"github.com/bloxapp/ssv-spec/qbft"
 	"github.com/bloxapp/ssv-spec/types"
+	"sort"
 )
 
 type testingStorage struct {
 	storage     map[string]*qbft.SignedMessage
+	decided     map[string]map[qbft.Height]*qbft.SignedMessage
 	operators   map[types.OperatorID]*dkg.Operator
 	keygenoupts map[string]*dkg.KeyGenOutput
+	snapshots   map[string][]byte
 }
 
 func NewTestingStorage() *testingStorage {
 	ret := &testingStorage{
 		storage:     make(map[string]*qbft.SignedMessage),
+		decided:     make(map[string]map[qbft.Height]*qbft.SignedMessage),
 		operators:   make(map[types.OperatorID]*dkg.Operator),
 		keygenoupts: make(map[string]*dkg.KeyGenOutput),
+		snapshots:   make(map[string][]byte),
 	}
 
 	for i, s := range Testing13SharesSet().DKGOperators {
@@ -42,6 +47,41 @@ func (s *testingStorage) GetHighestDecided(identifier []byte) (*qbft.SignedMessa
 	return s.storage[hex.EncodeToString(identifier)], nil
 }
 
+// SaveDecided saves the Decided msg for its identifier and height
+func (s *testingStorage) SaveDecided(signedMsg *qbft.SignedMessage) error {
+	id := hex.EncodeToString(signedMsg.Message.Identifier)
+	if s.decided[id] == nil {
+		s.decided[id] = make(map[qbft.Height]*qbft.SignedMessage)
+	}
+	s.decided[id][signedMsg.Message.Height] = signedMsg
+	return nil
+}
+
+// GetDecided returns all Decided msgs found for identifier between from and to heights (inclusive), sorted by height
+func (s *testingStorage) GetDecided(identifier []byte, from qbft.Height, to qbft.Height) ([]*qbft.SignedMessage, error) {
+	ret := make([]*qbft.SignedMessage, 0)
+	for h, msg := range s.decided[hex.EncodeToString(identifier)] {
+		if h >= from && h <= to {
+			ret = append(ret, msg)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Message.Height < ret[j].Message.Height
+	})
+	return ret, nil
+}
+
+// SaveControllerSnapshot saves (and overrides) the encoded controller snapshot for identifier
+func (s *testingStorage) SaveControllerSnapshot(identifier []byte, data []byte) error {
+	s.snapshots[hex.EncodeToString(identifier)] = data
+	return nil
+}
+
+// GetControllerSnapshot returns the encoded controller snapshot for identifier, nil if not found
+func (s *testingStorage) GetControllerSnapshot(identifier []byte) ([]byte, error) {
+	return s.snapshots[hex.EncodeToString(identifier)], nil
+}
+
 // GetDKGOperator returns true and operator object if found by operator ID
 func (s *testingStorage) GetDKGOperator(operatorID types.OperatorID) (bool, *dkg.Operator, error) {
 	if ret, found := s.operators[operatorID]; found {
@@ -1,6 +1,9 @@
 package testingutils
 
-import "github.com/bloxapp/ssv-spec/qbft"
+import (
+	"github.com/bloxapp/ssv-spec/qbft"
+	"time"
+)
 
 type TimerState struct {
 	Timeouts int
@@ -17,7 +20,7 @@ func NewTestingTimer() qbft.Timer {
 	}
 }
 
-func (t *TestQBFTTimer) TimeoutForRound(round qbft.Round) {
+func (t *TestQBFTTimer) TimeoutForRound(round qbft.Round, timeout time.Duration) {
 	t.State.Timeouts++
 	t.State.Round = round
 }